@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/bench"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/report"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// phaseStats holds the measurements of one phase (parse, part 1 or part 2) of
+// a day.
+type phaseStats struct {
+	Day   registry.Day
+	Phase string
+	bench.Stats
+}
+
+// benchCmd measures the parse step and both parts of one or all days.
+func benchCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("bench", stderr)
+	runs := flags.Int("runs", 10, "number of runs per phase")
+	inputPath := flags.String("input", "", "path to puzzle input, '-' reads from stdin")
+	format := formatFlag(flags)
+	options := registerDayOptions(flags)
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	days, err := selectDays(positional, *inputPath)
+	if err != nil {
+		return err
+	}
+
+	if *runs < 1 {
+		return fmt.Errorf("--runs %d: expected at least one run", *runs)
+	}
+
+	if err := options.validate(days); err != nil {
+		return err
+	}
+
+	var all []phaseStats
+	for _, day := range days {
+		stats, err := benchDay(day, options, *inputPath, *runs)
+		if err != nil {
+			return err
+		}
+		all = append(all, stats...)
+	}
+
+	return report.Write(stdout, *format, benchTable(all))
+}
+
+// benchDay reads the input of day into memory once and measures parsing it and
+// solving both parts.
+func benchDay(day registry.Day, options dayOptions, inputPath string, runs int) ([]phaseStats, error) {
+	puzzleInput, _, err := puzzleio.Resolve(inputPath, day.Input)
+	if err != nil {
+		return nil, fmt.Errorf("day %d: %w", day.Number, err)
+	}
+	defer puzzleInput.Close()
+
+	data, err := io.ReadAll(puzzleInput)
+	if err != nil {
+		return nil, fmt.Errorf("day %d: %w", day.Number, err)
+	}
+
+	var sol solution.Solution
+	parseStats, err := bench.Measure(runs, func() error {
+		sol = options.newSolution(day)
+		return nil
+	}, func() error {
+		return sol.Parse(bytes.NewReader(data))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("day %d: %w", day.Number, puzzleio.WithPath(err, puzzleInput.Path))
+	}
+
+	stats := []phaseStats{{Day: day, Phase: "parse", Stats: parseStats}}
+	for _, part := range []int{1, 2} {
+		partStats, err := bench.Measure(runs, nil, func() error {
+			_, err := solution.Part(sol, part)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("day %d part %d: %w", day.Number, part, err)
+		}
+
+		stats = append(stats, phaseStats{Day: day, Phase: fmt.Sprintf("part%d", part), Stats: partStats})
+	}
+
+	return stats, nil
+}
+
+// benchTable returns a table of all phases followed by the grand total of
+// their minimum and median times and of their allocations, i.e. those of
+// solving every day once. Percentiles do not add up, so the total has none.
+func benchTable(all []phaseStats) *report.Table {
+	table := report.NewTable("day", "slug", "phase", "runs", "min", "median", "p95", "allocs_per_op", "bytes_per_op")
+
+	var totalMin, totalMedian time.Duration
+	var totalAllocs, totalBytes uint64
+	for _, stats := range all {
+		table.Append(
+			stats.Day.Number, stats.Day.Slug, stats.Phase, stats.Runs,
+			stats.Min, stats.Median, stats.P95, stats.AllocsPerOp, stats.BytesPerOp,
+		)
+
+		totalMin += stats.Min
+		totalMedian += stats.Median
+		totalAllocs += stats.AllocsPerOp
+		totalBytes += stats.BytesPerOp
+	}
+
+	table.Append(nil, "total", nil, nil, totalMin, totalMedian, nil, totalAllocs, totalBytes)
+	return table
+}
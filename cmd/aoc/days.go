@@ -0,0 +1,9 @@
+package main
+
+// Every day registers itself with the registry when its package is imported.
+import (
+	_ "github.com/lo-b/aoc24/internal/days/ceressearch"
+	_ "github.com/lo-b/aoc24/internal/days/historianhysteria"
+	_ "github.com/lo-b/aoc24/internal/days/mullitover"
+	_ "github.com/lo-b/aoc24/internal/days/rednosedreports"
+)
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/lo-b/aoc24/internal/modroot"
+	"github.com/lo-b/aoc24/internal/puzzlepage"
+)
+
+// descriptionFile is the name of the Markdown description of a day, written
+// to the cmd/<slug> directory of the day.
+const descriptionFile = "PUZZLE.md"
+
+// describeCmd renders the puzzle page of a day as Markdown and stores it next
+// to the solution command of the day.
+func describeCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("describe", stderr)
+	source := registerPageFlags(flags)
+	root := flags.String("root", ".", "directory in the module to write the description in")
+	printOnly := flags.Bool("print", false, "print the description instead of writing it")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return errors.New("expected a day number or slug")
+	}
+
+	day, err := lookupDay(positional[0])
+	if err != nil {
+		return err
+	}
+
+	page, pagePath, err := source.read(day)
+	if err != nil {
+		return err
+	}
+
+	markdown := puzzlepage.Markdown(page)
+	if markdown == "" {
+		return fmt.Errorf("no puzzle description found in %s", pagePath)
+	}
+
+	if *printOnly {
+		_, err := io.WriteString(stdout, markdown)
+		return err
+	}
+
+	moduleRoot, err := modroot.Find(*root)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join("cmd", day.Slug)
+	if info, err := os.Stat(filepath.Join(moduleRoot, dir)); err != nil || !info.IsDir() {
+		return fmt.Errorf("no directory %s for day %d in %s", dir, day.Number, moduleRoot)
+	}
+
+	path := filepath.Join(dir, descriptionFile)
+	if err := os.WriteFile(filepath.Join(moduleRoot, path), []byte(markdown), 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout, path)
+
+	return nil
+}
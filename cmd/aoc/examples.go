@@ -0,0 +1,121 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/lo-b/aoc24/internal/modroot"
+	"github.com/lo-b/aoc24/internal/puzzlepage"
+	"github.com/lo-b/aoc24/internal/testutil"
+)
+
+// examplesCmd extracts the examples of a day from its puzzle page and writes
+// them as golden test cases, see package testutil.
+func examplesCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("examples", stderr)
+	source := registerPageFlags(flags)
+	root := flags.String("root", ".", "directory in the module to write the test cases in")
+	force := flags.Bool("force", false, "overwrite existing test cases")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return errors.New("expected a day number or slug")
+	}
+
+	day, err := lookupDay(positional[0])
+	if err != nil {
+		return err
+	}
+
+	page, pagePath, err := source.read(day)
+	if err != nil {
+		return err
+	}
+
+	cases := exampleCases(puzzlepage.Examples(page))
+	if len(cases) == 0 {
+		return fmt.Errorf("no examples found in %s", pagePath)
+	}
+
+	moduleRoot, err := modroot.Find(*root)
+	if err != nil {
+		return err
+	}
+
+	dir := testutil.Dir(moduleRoot, day.Slug)
+	for _, c := range cases {
+		inputPath := testutil.InputPath(dir, c.name)
+		if _, err := os.Stat(inputPath); err == nil && !*force {
+			return fmt.Errorf("%s already exists, use --force to overwrite it", inputPath)
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	for _, c := range cases {
+		if err := testutil.WriteCase(dir, c.name, c.input, c.answers); err != nil {
+			return err
+		}
+
+		written := []string{testutil.InputPath(dir, c.name)}
+		for idx, answer := range c.answers {
+			if answer != "" {
+				written = append(written, testutil.GoldenPath(dir, c.name, idx+1))
+			} else if c.parts[idx] {
+				fmt.Fprintf(stderr, "part %d: no answer found in the description, add %s\n", idx+1, filepath.Base(testutil.GoldenPath(dir, c.name, idx+1)))
+			}
+		}
+
+		for _, path := range written {
+			rel, err := filepath.Rel(moduleRoot, path)
+			if err != nil {
+				rel = path
+			}
+			fmt.Fprintln(stdout, rel)
+		}
+	}
+
+	return nil
+}
+
+// exampleCase is a golden test case made of the examples of one or both
+// parts sharing an input.
+type exampleCase struct {
+	name    string
+	input   string
+	answers [2]string
+	parts   [2]bool // parts using the input
+}
+
+// exampleCases groups examples with the same input into a single case. The
+// first case is named 'example', a case only used by part 2 'example2'.
+func exampleCases(examples []puzzlepage.Example) []exampleCase {
+	var cases []exampleCase
+	for _, example := range examples {
+		if n := len(cases); n > 0 && cases[n-1].input == example.Input {
+			cases[n-1].answers[example.Part-1] = example.Answer
+			cases[n-1].parts[example.Part-1] = true
+			continue
+		}
+
+		name := "example"
+		if len(cases) > 0 {
+			name = fmt.Sprintf("example%d", example.Part)
+		}
+
+		c := exampleCase{name: name, input: example.Input}
+		c.answers[example.Part-1] = example.Answer
+		c.parts[example.Part-1] = true
+		cases = append(cases, c)
+	}
+
+	return cases
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/leaderboard"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/report"
+)
+
+// leaderboardCmd shows the standings of a private leaderboard, or the star
+// times of its members on a single day.
+func leaderboardCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	sessionFile, _ := puzzleio.DefaultSessionFile()
+	cacheDir, _ := puzzleio.DefaultCacheDir()
+
+	flags := newFlagSet("leaderboard", stderr)
+	file := flags.String("file", "", "path to an exported leaderboard; fetched with --id when omitted")
+	id := flags.String("id", "", "ID of the private leaderboard to fetch")
+	baseURL := flags.String("base-url", puzzleio.DefaultBaseURL, "AoC-compatible endpoint to fetch the leaderboard from")
+	flags.StringVar(&sessionFile, "session-file", sessionFile, "file holding the session token")
+	flags.StringVar(&cacheDir, "cache-dir", cacheDir, "directory fetched leaderboards are cached in")
+	year := flags.Int("year", registry.Year, "year of the calendar")
+	day := flags.Int("day", 0, "show the star times of a single day instead of the standings")
+	format := formatFlag(flags)
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 0 {
+		return errors.New("expected no arguments")
+	}
+
+	if *day < 0 || *day > leaderboard.Days {
+		return fmt.Errorf("--day %d: expected a day from 1 to %d", *day, leaderboard.Days)
+	}
+
+	var board *leaderboard.Leaderboard
+	switch {
+	case *file != "":
+		board, err = leaderboard.Load(*file)
+	case *id != "":
+		var fetcher *leaderboard.Fetcher
+		if fetcher, err = leaderboard.NewFetcher(*baseURL, sessionFile, cacheDir); err == nil {
+			board, err = fetcher.Fetch(context.Background(), *year, *id)
+		}
+	default:
+		err = errors.New("expected a leaderboard --file or --id")
+	}
+	if err != nil {
+		return err
+	}
+
+	standings, err := leaderboard.Standings(board, time.Now())
+	if err != nil {
+		return err
+	}
+
+	table := standingsTable(standings)
+	if *day > 0 {
+		table = dayTable(standings, *day)
+	}
+
+	return report.Write(stdout, *format, table)
+}
+
+// standingsTable lists the score, stars and streaks of every member.
+func standingsTable(standings []leaderboard.Standing) *report.Table {
+	table := report.NewTable("rank", "member", "score", "stars", "streak", "current streak")
+	for idx, standing := range standings {
+		table.Append(idx+1, standing.Member.DisplayName(), standing.LocalScore, standing.Stars, standing.LongestStreak, standing.CurrentStreak)
+	}
+
+	return table
+}
+
+// dayTable lists the time each member took for the stars of day and the time
+// between them, ordered by the points earned.
+func dayTable(standings []leaderboard.Standing, day int) *report.Table {
+	type entry struct {
+		name   string
+		result leaderboard.DayResult
+	}
+
+	var entries []entry
+	for _, standing := range standings {
+		if result, ok := standing.Day(day); ok {
+			entries = append(entries, entry{standing.Member.DisplayName(), result})
+		}
+	}
+	slices.SortStableFunc(entries, func(a, b entry) int {
+		return cmp.Compare(b.result.Points, a.result.Points)
+	})
+
+	table := report.NewTable("rank", "member", "part1", "part2", "delta", "points")
+	for idx, entry := range entries {
+		table.Append(idx+1, entry.name, optionalDuration(entry.result.Part1), optionalDuration(entry.result.Part2), optionalDuration(entry.result.Delta), entry.result.Points)
+	}
+
+	return table
+}
+
+// optionalDuration returns d, or nil for an empty cell when d is zero.
+func optionalDuration(d time.Duration) any {
+	if d == 0 {
+		return nil
+	}
+
+	return d
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/report"
+)
+
+// listCmd prints every registered day.
+func listCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("list", stderr)
+	format := formatFlag(flags)
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) > 0 {
+		return fmt.Errorf("unexpected arguments %v", positional)
+	}
+
+	table := report.NewTable("day", "slug", "input")
+	for _, day := range registry.All() {
+		table.Append(day.Number, day.Slug, day.Input)
+	}
+
+	return report.Write(stdout, *format, table)
+}
@@ -0,0 +1,146 @@
+// Command aoc runs the registered puzzle solutions of the calendar.
+//
+// Usage:
+//
+//	aoc list [--format f]
+//	aoc run <day|slug|all> [--part 1|2] [--input path] [--workers n] [--timeout d] [--format f]
+//	aoc verify [day|slug|all] [--answers path] [--record] [--format f]
+//	aoc bench [day|slug|all] [--runs n] [--input path] [--format f]
+//	aoc new <day> <slug> [--root dir]
+//	aoc submit <day|slug> <part> [--input path] [--base-url url] [--session-file path]
+//	aoc watch <day|slug> [--input path] [--interval d] [--once]
+//	aoc examples <day|slug> [--page path] [--base-url url] [--force]
+//	aoc describe <day|slug> [--page path] [--base-url url] [--print]
+//	aoc leaderboard [--file path | --id id] [--day n] [--format f]
+//
+// Results are written as text, json, jsonl, csv or markdown, see --format.
+//
+// Flags not given on the command line are read from environment variables,
+// e.g. AOC_RUN_FORMAT or AOC_FORMAT, or the JSON config file aoc.json in the
+// repository root; see package config. The run and bench commands also take
+// the runtime options of the days, e.g. --red-nosed-reports.max-level-diff,
+// and so does the watch command, which passes them on to the rebuilt runner.
+//
+// The run command of a single day takes --cpuprofile, --memprofile, --trace
+// and --blockprofile, profiling parsing and solving only, see package
+// profiling; so do the standalone solution commands.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/lo-b/aoc24/internal/config"
+	"github.com/lo-b/aoc24/internal/report"
+)
+
+// command is a subcommand of aoc.
+type command struct {
+	name    string
+	usage   string
+	summary string
+	run     func(args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+// commands lists all subcommands, in the order they are shown in the usage.
+var commands []command
+
+func init() {
+	commands = []command{
+		{"list", "list [--format f]", "list the registered days", listCmd},
+		{"run", "run <day|slug|all> [--part 1|2] [--input path] [--workers n] [--timeout d] [--format f]", "run the solution of a day, or of all days", runCmd},
+		{"verify", "verify [day|slug|all] [--answers path] [--record] [--format f]", "compare answers with the known answers", verifyCmd},
+		{"bench", "bench [day|slug|all] [--runs n] [--input path] [--format f]", "time parsing and both parts of a day, or of all days", benchCmd},
+		{"new", "new <day> <slug> [--root dir]", "generate the files of a new day and register it", newCmd},
+		{"submit", "submit <day|slug> <part> [--input path] [--base-url url] [--session-file path]", "submit the answer to a part and record it when correct", submitCmd},
+		{"watch", "watch <day|slug> [--input path] [--interval d] [--once]", "rebuild, test and re-run a day when its code or input changes", watchCmd},
+		{"examples", "examples <day|slug> [--page path] [--base-url url] [--force]", "write the examples of a puzzle page as golden test cases", examplesCmd},
+		{"describe", "describe <day|slug> [--page path] [--base-url url] [--print]", "render the puzzle page of a day as Markdown in cmd/<slug>", describeCmd},
+		{"leaderboard", "leaderboard [--file path | --id id] [--day n] [--format f]", "show the standings of a private leaderboard, or the star times of a day", leaderboardCmd},
+	}
+}
+
+func main() {
+	os.Exit(realMain(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// realMain dispatches args to the matching subcommand and returns the exit
+// code of the process.
+func realMain(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage(stderr)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		err := cmd.run(args[1:], stdout, stderr)
+		if errors.Is(err, flag.ErrHelp) {
+			return 2
+		}
+
+		if err != nil {
+			fmt.Fprintf(stderr, "aoc %s: %v\n", cmd.name, err)
+			return 1
+		}
+
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "aoc: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  aoc %s\n    \t%s\n", cmd.usage, cmd.summary)
+	}
+}
+
+// newFlagSet returns a flag set for the subcommand cmd which reports errors
+// instead of exiting. It includes the --config flag, see parseArgs.
+func newFlagSet(cmd string, stderr io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet("aoc "+cmd, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	config.Register(flags, cmd)
+
+	return flags
+}
+
+// parseArgs parses args with flags, allowing flags and positional arguments to
+// be interspersed (e.g. 'run 4 --part 2'), and returns the positional ones.
+// Flags not given in args are set from the environment or config file, see
+// package config.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, config.Apply(flags, strings.TrimPrefix(flags.Name(), "aoc "))
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// formatFlag registers the --format flag on flags.
+func formatFlag(flags *flag.FlagSet) *report.Format {
+	format := report.Text
+	flags.Var(&format, "format", fmt.Sprintf("output format, one of %v", report.Formats))
+
+	return &format
+}
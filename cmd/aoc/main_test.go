@@ -0,0 +1,571 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/bench"
+	"github.com/lo-b/aoc24/internal/modroot"
+	"github.com/lo-b/aoc24/internal/pool"
+	"github.com/lo-b/aoc24/internal/profiling"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/solution"
+	"github.com/lo-b/aoc24/internal/submit/submittest"
+	"github.com/lo-b/aoc24/internal/testutil"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		wantPositional []string
+		wantPart       int
+	}{
+		{"flag after positional", []string{"4", "--part", "2"}, []string{"4"}, 2},
+		{"flag before positional", []string{"-part=1", "all"}, []string{"all"}, 1},
+		{"no flags", []string{"ceres-search"}, []string{"ceres-search"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := newFlagSet("test", &bytes.Buffer{})
+			part := flags.Int("part", 0, "")
+
+			positional, err := parseArgs(flags, tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(positional, tt.wantPositional) || *part != tt.wantPart {
+				t.Errorf("got %v (part %d), want %v (part %d)", positional, *part, tt.wantPositional, tt.wantPart)
+			}
+		})
+	}
+}
+
+// historianExample returns the absolute path of the site example of day 1 in
+// the golden files of the repository, see package testutil.
+func historianExample(t *testing.T) string {
+	t.Helper()
+
+	root, err := modroot.Find(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testutil.InputPath(testutil.Dir(root, "historian-hysteria"), "example")
+}
+
+func TestRealMain(t *testing.T) {
+	example := historianExample(t)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout []string
+	}{
+		{
+			name:       "list registered days",
+			args:       []string{"list"},
+			wantCode:   0,
+			wantStdout: []string{"historian-hysteria", "red-nosed-reports", "mull-it-over", "ceres-search"},
+		},
+		{
+			name:       "list registered days as csv",
+			args:       []string{"list", "--format", "csv"},
+			wantCode:   0,
+			wantStdout: []string{"day,slug,input\n", "1,historian-hysteria,location_ids.txt\n"},
+		},
+		{
+			name:       "run site example of day 1",
+			args:       []string{"run", "1", "--input", example, "--format", "csv"},
+			wantCode:   0,
+			wantStdout: []string{"day,slug,part,answer,input\n", "1,historian-hysteria,1,11,", "1,historian-hysteria,2,31,"},
+		},
+		{
+			name:       "run single part by slug",
+			args:       []string{"run", "historian-hysteria", "--part", "2", "--input", example, "--format", "jsonl"},
+			wantCode:   0,
+			wantStdout: []string{`{"day":1,"slug":"historian-hysteria","part":2,"answer":31,`},
+		},
+		{
+			name:       "bench site example of day 1",
+			args:       []string{"bench", "1", "--runs", "2", "--input", example},
+			wantCode:   0,
+			wantStdout: []string{"parse", "part1", "part2", "total"},
+		},
+		{"unregistered day", []string{"run", "25"}, 1, nil},
+		{"unknown format", []string{"run", "1", "--format", "yaml"}, 1, nil},
+		{"no workers", []string{"run", "all", "--workers", "0"}, 1, nil},
+		{"invalid day option", []string{"run", "2", "--red-nosed-reports.max-level-diff", "0"}, 1, nil},
+		{"invalid part", []string{"run", "1", "--part", "3"}, 1, nil},
+		{"profile all days", []string{"run", "all", "--cpuprofile", os.DevNull}, 1, nil},
+		{"watch without day", []string{"watch"}, 1, nil},
+		{"watch stdin", []string{"watch", "1", "--input", "-"}, 1, nil},
+		{"watch invalid day option", []string{"watch", "2", "--once", "--red-nosed-reports.max-level-diff", "0"}, 1, nil},
+		{"unknown command", []string{"frobnicate"}, 2, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := realMain(tt.args, &stdout, &stderr)
+
+			if code != tt.wantCode {
+				t.Errorf("got exit code %d, want %d (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("expected stdout to contain %q, got:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	answersPath := filepath.Join(t.TempDir(), "answers.json")
+
+	var stdout, stderr bytes.Buffer
+	if code := realMain([]string{"verify", "1", "--answers", answersPath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "UNKNOWN") {
+		t.Errorf("expected unknown answers before recording, got:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := realMain([]string{"verify", "1", "--answers", answersPath, "--record"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+
+	stdout.Reset()
+	if code := realMain([]string{"verify", "1", "--answers", answersPath}, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+	if strings.Count(stdout.String(), "PASS") != 2 {
+		t.Errorf("expected both parts to pass after recording, got:\n%s", stdout.String())
+	}
+
+	// NOTE: tamper with a recorded answer to make verification fail
+	data, err := os.ReadFile(answersPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := regexp.MustCompile(`"answer": \d+`).ReplaceAll(data, []byte(`"answer": 42`))
+	if err := os.WriteFile(answersPath, tampered, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	if code := realMain([]string{"verify", "1", "--answers", answersPath}, &stdout, &stderr); code != 1 {
+		t.Errorf("got exit code %d, want 1", code)
+	}
+	if !strings.Contains(stdout.String(), "FAIL") || !strings.Contains(stdout.String(), "2904518   42") {
+		t.Errorf("expected failure with diff, got:\n%s", stdout.String())
+	}
+}
+
+func TestVerify_ReportsFailingDays(t *testing.T) {
+	example, err := os.ReadFile(historianExample(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: only day 1 has an input, the other days fail to read theirs
+	inputDir := t.TempDir()
+	writeFiles(t, inputDir, map[string]string{"location_ids.txt": string(example)})
+	t.Setenv(puzzleio.InputDirEnv, inputDir)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"verify", "all", "--answers", filepath.Join(t.TempDir(), "answers.json"), "--format", "csv"}
+	if code := realMain(args, &stdout, &stderr); code != 1 {
+		t.Errorf("got exit code %d, want 1", code)
+	}
+
+	if got := strings.Count(stdout.String(), "UNKNOWN,1,"); got != 2 {
+		t.Errorf("expected both parts of day 1 to be verified, got:\n%s", stdout.String())
+	}
+	for _, want := range []string{"ERROR,2,", "ERROR,3,", "ERROR,4,", "unable to read input file"} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("expected stdout to contain %q, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+func TestBenchTable(t *testing.T) {
+	day := registry.Day{Number: 1, Slug: "historian-hysteria"}
+	table := benchTable([]phaseStats{
+		{day, "parse", bench.Stats{Runs: 2, Min: time.Millisecond, Median: 2 * time.Millisecond, AllocsPerOp: 10, BytesPerOp: 1024}},
+		{day, "part1", bench.Stats{Runs: 2, Min: time.Millisecond, Median: time.Millisecond, AllocsPerOp: 1, BytesPerOp: 16}},
+	})
+
+	want := []any{nil, "total", nil, nil, 2 * time.Millisecond, 3 * time.Millisecond, nil, uint64(11), uint64(1040)}
+	if got := table.Rows[len(table.Rows)-1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDayOptionsArgs(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	options := registerDayOptions(flags)
+	if err := flags.Parse([]string{"--red-nosed-reports.max-level-diff", "5", "--ceres-search.word", "SAMX"}); err != nil {
+		t.Fatal(err)
+	}
+
+	day, err := lookupDay("red-nosed-reports")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"--red-nosed-reports.max-level-diff=5"}
+	if got := options.args(flags, day); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestRunProfiles(t *testing.T) {
+	dir := t.TempDir()
+	example := historianExample(t)
+
+	profiles := []string{"cpu.pprof", "mem.pprof", "trace.out", "block.pprof"}
+	args := []string{"run", "1", "--input", example}
+	for idx, flagName := range []string{"--cpuprofile", "--memprofile", "--trace", "--blockprofile"} {
+		args = append(args, flagName, filepath.Join(dir, profiles[idx]))
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := realMain(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+
+	for _, name := range profiles {
+		if info, err := os.Stat(filepath.Join(dir, name)); err != nil || info.Size() == 0 {
+			t.Errorf("expected %s to be written, got %v", name, err)
+		}
+	}
+}
+
+func TestWatchOnce(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the runner and runs the tests of a day")
+	}
+
+	example := historianExample(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := realMain([]string{"watch", "1", "--once", "--input", example}, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s, stdout: %s)", code, stderr.String(), stdout.String())
+	}
+
+	for _, want := range []string{"build: ok", "tests: ok", "1               11", "2               31", "run time: "} {
+		if !strings.Contains(stdout.String(), want) {
+			t.Errorf("expected stdout to contain %q, got:\n%s", want, stdout.String())
+		}
+	}
+}
+
+// writeFiles writes files, content by path relative to dir, failing the test
+// on the first error.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, path), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	dir := t.TempDir()
+	example := historianExample(t)
+	sessionFile := filepath.Join(dir, "session")
+	answersPath := filepath.Join(dir, "answers.json")
+	writeFiles(t, dir, map[string]string{"session": "secret\n"})
+
+	server := submittest.NewServer(t, "secret", map[submittest.Key]string{
+		{Year: 2024, Day: 1, Part: 1}: "11",
+		{Year: 2024, Day: 1, Part: 2}: "31",
+	})
+
+	submitArgs := func(part string) []string {
+		return []string{
+			"submit", "1", part, "--input", example,
+			"--base-url", server.URL, "--session-file", sessionFile,
+			"--state-file", filepath.Join(dir, "submit.json"), "--answers", answersPath,
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := realMain(submitArgs("2"), &stdout, &stderr); code != 1 {
+		t.Errorf("got exit code %d, want 1 submitting a locked part", code)
+	}
+	if !strings.Contains(stdout.String(), "wrong level") {
+		t.Errorf("expected wrong level, got:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := realMain(submitArgs("1"), &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "answer 11 is correct") {
+		t.Errorf("expected correct answer, got:\n%s", stdout.String())
+	}
+
+	data, err := os.ReadFile(answersPath)
+	if err != nil {
+		t.Fatalf("expected correct answer to be recorded: %v", err)
+	}
+	if !strings.Contains(string(data), `"answer": 11`) {
+		t.Errorf("expected answer 11 to be recorded, got:\n%s", data)
+	}
+
+	stdout.Reset()
+	if code := realMain(submitArgs("1"), &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "already known") || server.Requests() != 2 {
+		t.Errorf("expected known answer not to be submitted again, got %d requests:\n%s", server.Requests(), stdout.String())
+	}
+}
+
+func TestExamples(t *testing.T) {
+	const page = `<main>
+<article class="day-desc"><h2>--- Day 1: Historian Hysteria ---</h2>
+<pre><code>3   4
+4   3
+2   5
+1   3
+3   9
+3   3
+</code></pre>
+<p>In the example above, this is <code>2 + 1 + 0 + 1 + 2 + 5</code>, a total distance of <code><em>11</em></code>!</p>
+</article>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<p>So, for these example lists, the similarity score at the end of this process is <code><em>31</em></code>.</p>
+</article>
+</main>`
+
+	dir := t.TempDir()
+	sessionFile := filepath.Join(dir, "session")
+	writeFiles(t, dir, map[string]string{
+		"go.mod":  "module example.com/aoc\n",
+		"session": "secret\n",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2024/day/1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(page))
+	}))
+	t.Cleanup(server.Close)
+
+	args := []string{"examples", "1", "--root", dir, "--base-url", server.URL, "--session-file", sessionFile, "--cache-dir", filepath.Join(dir, "cache")}
+
+	var stdout, stderr bytes.Buffer
+	if code := realMain(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+
+	example, err := os.ReadFile(historianExample(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caseDir := filepath.Join(dir, "testdata", "historian-hysteria")
+	want := map[string]string{
+		"example.in":    string(example),
+		"example.part1": "11\n",
+		"example.part2": "31\n",
+	}
+	for name, content := range want {
+		data, err := os.ReadFile(filepath.Join(caseDir, name))
+		if err != nil || string(data) != content {
+			t.Errorf("%s: got %q (error: %v), want %q", name, data, err, content)
+		}
+	}
+
+	if code := realMain(args, &stdout, &stderr); code != 1 {
+		t.Errorf("got exit code %d, want 1 for existing cases", code)
+	}
+
+	// NOTE: the page is cached now, so the endpoint is not needed anymore
+	server.Close()
+	if code := realMain(append(args, "--force"), &stdout, &stderr); code != 0 {
+		t.Errorf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	const page = `<main>
+<article class="day-desc"><h2>--- Day 1: Historian Hysteria ---</h2>
+<p>For example:</p>
+<pre><code>3   4
+4   3
+</code></pre>
+<p>A total distance of <code><em>11</em></code>!</p>
+</article>
+<p>Your puzzle answer was <code>2904518</code>.</p>
+</main>`
+
+	dir := t.TempDir()
+	pagePath := filepath.Join(dir, "day01.html")
+	writeFiles(t, dir, map[string]string{
+		"go.mod":     "module example.com/aoc\n",
+		"day01.html": page,
+	})
+
+	args := []string{"describe", "historian-hysteria", "--page", pagePath, "--root", dir}
+
+	var stdout, stderr bytes.Buffer
+	if code := realMain(args, &stdout, &stderr); code != 1 {
+		t.Errorf("got exit code %d, want 1 without a cmd directory for the day", code)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "cmd", "historian-hysteria"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	stdout.Reset()
+	if code := realMain(args, &stdout, &stderr); code != 0 {
+		t.Fatalf("got exit code %d, want 0 (stderr: %s)", code, stderr.String())
+	}
+
+	want := "## Day 1: Historian Hysteria\n\nFor example:\n\n```\n3   4\n4   3\n```\n\nA total distance of **`11`**!\n"
+	data, err := os.ReadFile(filepath.Join(dir, "cmd", "historian-hysteria", "PUZZLE.md"))
+	if err != nil || string(data) != want {
+		t.Errorf("got %q (error: %v), want %q", data, err, want)
+	}
+
+	stdout.Reset()
+	if code := realMain(append(args, "--print"), &stdout, &stderr); code != 0 || stdout.String() != want {
+		t.Errorf("got exit code %d and %q, want 0 and %q", code, stdout.String(), want)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	const export = `{"event": "2024", "owner_id": 1, "members": {
+		"1": {"id": 1, "name": "alice", "completion_day_level": {
+			"1": {"1": {"get_star_ts": 1733029800, "star_index": 2}, "2": {"get_star_ts": 1733030100, "star_index": 3}}}},
+		"2": {"id": 2, "name": "bob", "completion_day_level": {
+			"1": {"1": {"get_star_ts": 1733029500, "star_index": 1}}}}}}`
+
+	dir := t.TempDir()
+	exportPath := filepath.Join(dir, "leaderboard.json")
+	sessionFile := filepath.Join(dir, "session")
+	writeFiles(t, dir, map[string]string{
+		"leaderboard.json": export,
+		"session":          "secret\n",
+	})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/2024/leaderboard/private/view/1.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(export))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout []string
+	}{
+		{
+			name:       "standings from file",
+			args:       []string{"leaderboard", "--file", exportPath, "--format", "csv"},
+			wantStdout: []string{"rank,member,score,stars,streak,current streak\n", "1,alice,3,2,1,", "2,bob,2,1,0,0\n"},
+		},
+		{
+			name:       "day from endpoint",
+			args:       []string{"leaderboard", "--id", "1", "--base-url", server.URL, "--session-file", sessionFile, "--cache-dir", dir, "--day", "1", "--format", "jsonl"},
+			wantStdout: []string{`{"rank":1,"member":"alice","part1":600000000000,"part2":900000000000,"delta":300000000000,"points":3}`, `{"rank":2,"member":"bob","part1":300000000000,"part2":null,"delta":null,"points":2}`},
+		},
+		{"no source", []string{"leaderboard"}, 1, nil},
+		{"invalid day", []string{"leaderboard", "--file", exportPath, "--day", "26"}, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := realMain(tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("got exit code %d, want %d (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("expected stdout to contain %q, got:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
+
+// panickingSolution fails like an out of range index in a solution.
+type panickingSolution struct{ levels []int }
+
+func (s *panickingSolution) Parse(input io.Reader) error { return nil }
+func (s *panickingSolution) Part1() (solution.Answer, error) {
+	return solution.Int(s.levels[1]), nil
+}
+func (s *panickingSolution) Part2() (solution.Answer, error) { return solution.Int(0), nil }
+
+// slowSolution takes longer than any test timeout.
+type slowSolution struct{}
+
+func (s *slowSolution) Parse(input io.Reader) error { return nil }
+func (s *slowSolution) Part1() (solution.Answer, error) {
+	time.Sleep(time.Second)
+	return solution.Int(0), nil
+}
+func (s *slowSolution) Part2() (solution.Answer, error) { return solution.Int(0), nil }
+
+func TestSolveDays(t *testing.T) {
+	example := historianExample(t)
+
+	historian, err := lookupDay("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	days := []registry.Day{
+		{Number: 23, Slug: "panicking", New: func() solution.Solution { return &panickingSolution{} }},
+		historian,
+		{Number: 24, Slug: "slow", New: func() solution.Solution { return &slowSolution{} }},
+	}
+
+	results := solveDays(days, nil, profiling.Options{}, example, []int{1, 2}, 2, 100*time.Millisecond)
+
+	var panicErr *pool.PanicError
+	if !errors.As(results[0].Err, &panicErr) {
+		t.Errorf("got error %v, want a panic", results[0].Err)
+	}
+
+	if results[1].Err != nil || len(results[1].Value.Parts) != 2 || results[1].Value.Parts[0].Answer != solution.Int(11) {
+		t.Errorf("got %+v, want answers to the example despite the failing days", results[1])
+	}
+
+	if !errors.Is(results[2].Err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", results[2].Err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/lo-b/aoc24/internal/modroot"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/scaffold"
+)
+
+// newCmd generates the files of a new day and registers it with the runner.
+func newCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("new", stderr)
+	root := flags.String("root", ".", "directory in the module to generate the day in")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 2 {
+		return errors.New("expected a day number and a slug")
+	}
+
+	number, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("invalid day %q", positional[0])
+	}
+
+	day := scaffold.Day{Number: number, Slug: positional[1]}
+	if registered, ok := registry.Lookup(day.Number); ok {
+		return fmt.Errorf("day %d is already registered as %s", day.Number, registered.Slug)
+	}
+	if registered, ok := registry.LookupSlug(day.Slug); ok {
+		return fmt.Errorf("%s is already registered as day %d", day.Slug, registered.Number)
+	}
+
+	moduleRoot, err := modroot.Find(*root)
+	if err != nil {
+		return err
+	}
+
+	written, err := scaffold.Generate(moduleRoot, day)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range written {
+		fmt.Fprintln(stdout, path)
+	}
+
+	return nil
+}
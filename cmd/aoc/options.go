@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// dayOptions holds the runtime options of the registered days, keyed by day
+// number.
+type dayOptions map[int]solution.Options
+
+// registerDayOptions registers the runtime options of every registered day on
+// flags, prefixing their names with the slug of the day, e.g.
+// --ceres-search.word.
+func registerDayOptions(flags *flag.FlagSet) dayOptions {
+	options := make(dayOptions)
+	for _, day := range registry.All() {
+		if day.Options == nil {
+			continue
+		}
+
+		options[day.Number] = day.Options()
+		options[day.Number].Flags(flags, day.Slug+".")
+	}
+
+	return options
+}
+
+// validate returns an error if the options of any of days are invalid.
+func (o dayOptions) validate(days []registry.Day) error {
+	for _, day := range days {
+		if options, ok := o[day.Number]; ok {
+			if err := options.Validate(); err != nil {
+				return fmt.Errorf("%s: %w", day.Slug, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// args returns the options of day that were set on flags, e.g. from the
+// command line or config file, as arguments for another aoc command.
+func (o dayOptions) args(flags *flag.FlagSet, day registry.Day) []string {
+	if _, ok := o[day.Number]; !ok {
+		return nil
+	}
+
+	var args []string
+	flags.Visit(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Name, day.Slug+".") {
+			args = append(args, "--"+f.Name+"="+f.Value.String())
+		}
+	})
+
+	return args
+}
+
+// newSolution returns a new solution of day using its options, or the
+// defaults if o holds none.
+func (o dayOptions) newSolution(day registry.Day) solution.Solution {
+	if options, ok := o[day.Number]; ok {
+		return options.New()
+	}
+
+	return day.New()
+}
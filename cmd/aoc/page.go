@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+)
+
+// pageFlags are the flags of the commands reading the puzzle page of a day.
+type pageFlags struct {
+	path        string
+	baseURL     string
+	sessionFile string
+	cacheDir    string
+	year        int
+}
+
+// registerPageFlags registers the flags selecting the puzzle page on flags.
+func registerPageFlags(flags *flag.FlagSet) *pageFlags {
+	page := &pageFlags{}
+	page.sessionFile, _ = puzzleio.DefaultSessionFile()
+	page.cacheDir, _ = puzzleio.DefaultCacheDir()
+
+	flags.StringVar(&page.path, "page", "", "path to a saved puzzle page; fetched from --base-url when omitted")
+	flags.StringVar(&page.baseURL, "base-url", puzzleio.DefaultBaseURL, "AoC-compatible endpoint to fetch the puzzle page from")
+	flags.StringVar(&page.sessionFile, "session-file", page.sessionFile, "file holding the session token")
+	flags.StringVar(&page.cacheDir, "cache-dir", page.cacheDir, "directory fetched puzzle pages are cached in")
+	flags.IntVar(&page.year, "year", registry.Year, "year of the calendar")
+
+	return page
+}
+
+// read returns the puzzle page of day and its path, fetching the page unless
+// it was given with --page or is cached already.
+func (p *pageFlags) read(day registry.Day) (page string, path string, err error) {
+	path = p.path
+	if path == "" {
+		fetcher, err := puzzleio.NewFetcher(p.baseURL, p.sessionFile, p.cacheDir)
+		if err != nil {
+			return "", "", err
+		}
+
+		if path, err = fetcher.FetchPuzzle(context.Background(), p.year, day.Number); err != nil {
+			return "", "", err
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(data), path, nil
+}
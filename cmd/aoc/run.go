@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/pool"
+	"github.com/lo-b/aoc24/internal/profiling"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/report"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// runCmd runs the solution of one or all days.
+func runCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("run", stderr)
+	part := flags.Int("part", 0, "part to run, 1 or 2; runs both parts when omitted")
+	inputPath := flags.String("input", "", "path to puzzle input, '-' reads from stdin")
+	format := formatFlag(flags)
+	workers := flags.Int("workers", runtime.GOMAXPROCS(0), "number of days solved concurrently")
+	timeout := flags.Duration("timeout", 0, "maximum time to solve a day, e.g. 30s; unlimited when 0")
+	options := registerDayOptions(flags)
+	var profiles profiling.Options
+	profiles.Flags(flags)
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return errors.New("expected a single day number, slug or 'all'")
+	}
+
+	parts, err := selectParts(*part)
+	if err != nil {
+		return err
+	}
+
+	days, err := selectDays(positional, *inputPath)
+	if err != nil {
+		return err
+	}
+
+	if err := options.validate(days); err != nil {
+		return err
+	}
+
+	if profiles.Enabled() && len(days) > 1 {
+		return errors.New("profiling needs a single day")
+	}
+
+	if *workers < 1 {
+		return fmt.Errorf("--workers %d: expected at least one worker", *workers)
+	}
+
+	if *timeout < 0 {
+		return fmt.Errorf("--timeout %v: expected a positive duration", *timeout)
+	}
+
+	var failed int
+	table := report.NewTable("day", "slug", "part", "answer", "input")
+	for _, run := range solveDays(days, options, profiles, *inputPath, parts, *workers, *timeout) {
+		day := days[run.Index]
+		if run.Err != nil {
+			failed++
+			fmt.Fprintf(stderr, "day %d: FAIL: %v\n", day.Number, run.Err)
+			continue
+		}
+
+		result := run.Value
+		fmt.Fprintf(stderr, "day %d: input %s from %s (%v)\n", day.Number, result.Input.Short(), result.Path, result.Source)
+		for _, part := range result.Parts {
+			table.Append(day.Number, day.Slug, part.Part, part.Answer, result.Input)
+		}
+	}
+
+	if err := report.Write(stdout, *format, table); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d days failed", failed, len(days))
+	}
+
+	return nil
+}
+
+// solveDays solves days concurrently on workers, see solveDay, limiting each
+// day to timeout when it is positive. A day that panics or times out fails
+// without affecting the others.
+func solveDays(days []registry.Day, options dayOptions, profiles profiling.Options, inputPath string, parts []int, workers int, timeout time.Duration) []pool.Result[dayResult] {
+	return pool.Run(context.Background(), days, workers, timeout, func(ctx context.Context, day registry.Day) (dayResult, error) {
+		return solveDay(ctx, day, options, profiles, inputPath, parts)
+	})
+}
+
+// selectDays returns the days selected by the optional positional argument: a
+// day number, slug or 'all'. Without an argument all days are selected. An
+// input path can only be used with a single day.
+func selectDays(positional []string, inputPath string) ([]registry.Day, error) {
+	if len(positional) > 1 {
+		return nil, errors.New("expected a single day number, slug or 'all'")
+	}
+
+	if len(positional) == 0 || positional[0] == "all" {
+		if inputPath != "" {
+			return nil, errors.New("--input cannot be combined with 'all'")
+		}
+
+		return registry.All(), nil
+	}
+
+	day, err := lookupDay(positional[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []registry.Day{day}, nil
+}
+
+// lookupDay finds a registered day by number or slug.
+func lookupDay(arg string) (registry.Day, error) {
+	if number, err := strconv.Atoi(arg); err == nil {
+		if day, ok := registry.Lookup(number); ok {
+			return day, nil
+		}
+
+		return registry.Day{}, fmt.Errorf("day %d is not registered", number)
+	}
+
+	if day, ok := registry.LookupSlug(arg); ok {
+		return day, nil
+	}
+
+	return registry.Day{}, fmt.Errorf("no day registered as %q", arg)
+}
+
+// selectParts returns the parts to run for the --part flag value.
+func selectParts(part int) ([]int, error) {
+	switch part {
+	case 0:
+		return []int{1, 2}, nil
+	case 1, 2:
+		return []int{part}, nil
+	}
+
+	return nil, fmt.Errorf("--part %d: %w", part, solution.ErrInvalidPart)
+}
+
+// dayResult holds the answers of a day to a single puzzle input.
+type dayResult struct {
+	Day    registry.Day
+	Input  puzzleio.Fingerprint
+	Path   string
+	Source puzzleio.Source
+	Parts  []partResult
+}
+
+// partResult holds the answer to a single part.
+type partResult struct {
+	Part   int
+	Answer solution.Answer
+}
+
+// solveDay resolves the input of day, parses it once and solves every part
+// using the options of the day. Profiles cover parsing and solving only, not
+// resolving or fingerprinting the input. It stops before the next part once
+// ctx is done. Errors are not prefixed with the day, callers add it.
+func solveDay(ctx context.Context, day registry.Day, options dayOptions, profiles profiling.Options, inputPath string, parts []int) (dayResult, error) {
+	puzzleInput, source, err := puzzleio.Resolve(inputPath, day.Input)
+	if err != nil {
+		return dayResult{}, err
+	}
+	defer puzzleInput.Close()
+
+	sol := options.newSolution(day)
+	stopProfiles, err := profiles.Start()
+	if err != nil {
+		return dayResult{}, err
+	}
+
+	results, err := solveParts(ctx, sol, puzzleInput, parts)
+	if err := errors.Join(err, stopProfiles()); err != nil {
+		return dayResult{}, err
+	}
+
+	fingerprint, err := puzzleInput.Fingerprint()
+	if err != nil {
+		return dayResult{}, err
+	}
+
+	return dayResult{
+		Day:    day,
+		Input:  fingerprint,
+		Path:   puzzleInput.Path,
+		Source: source,
+		Parts:  results,
+	}, nil
+}
+
+// solveParts parses puzzleInput with sol and solves every part, stopping
+// before the next part once ctx is done.
+func solveParts(ctx context.Context, sol solution.Solution, puzzleInput *puzzleio.PuzzleInput, parts []int) ([]partResult, error) {
+	if err := sol.Parse(puzzleInput); err != nil {
+		return nil, puzzleio.WithPath(err, puzzleInput.Path)
+	}
+
+	var results []partResult
+	for _, part := range parts {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("part %d: %w", part, err)
+		}
+
+		answer, err := solution.Part(sol, part)
+		if err != nil {
+			return nil, fmt.Errorf("part %d: %w", part, err)
+		}
+
+		results = append(results, partResult{Part: part, Answer: answer})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/lo-b/aoc24/internal/answers"
+	"github.com/lo-b/aoc24/internal/profiling"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/solution"
+	"github.com/lo-b/aoc24/internal/submit"
+)
+
+// submitCmd solves a part of a day and posts the answer to the endpoint,
+// recording correct answers as known answers.
+func submitCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	sessionFile, _ := puzzleio.DefaultSessionFile()
+	stateFile, _ := submit.DefaultStateFile()
+
+	flags := newFlagSet("submit", stderr)
+	inputPath := flags.String("input", "", "path to puzzle input, '-' reads from stdin")
+	baseURL := flags.String("base-url", puzzleio.DefaultBaseURL, "AoC-compatible endpoint to submit to")
+	flags.StringVar(&sessionFile, "session-file", sessionFile, "file holding the session token")
+	flags.StringVar(&stateFile, "state-file", stateFile, "file the submission cooldowns are kept in")
+	answersPath := flags.String("answers", answers.DefaultPath(), "path to the known answers file")
+	year := flags.Int("year", registry.Year, "year of the calendar")
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 2 {
+		return errors.New("expected a day number or slug and a part")
+	}
+
+	day, err := lookupDay(positional[0])
+	if err != nil {
+		return err
+	}
+
+	part, err := strconv.Atoi(positional[1])
+	if err != nil || (part != 1 && part != 2) {
+		return fmt.Errorf("part %s: %w", positional[1], solution.ErrInvalidPart)
+	}
+
+	result, err := solveDay(context.Background(), day, nil, profiling.Options{}, *inputPath, []int{part})
+	if err != nil {
+		return fmt.Errorf("day %d: %w", day.Number, err)
+	}
+	answer := result.Parts[0].Answer
+
+	store, err := answers.Load(*answersPath)
+	if err != nil {
+		return err
+	}
+
+	key := answers.Key{Day: day.Number, Part: part, Input: result.Input}
+	switch status, known := store.Check(key, answer); status {
+	case answers.Pass:
+		fmt.Fprintf(stdout, "day %d part %d: answer %v is already known to be correct\n", day.Number, part, answer)
+		return nil
+	case answers.Fail:
+		return fmt.Errorf("answer %v differs from the known answer %v, not submitting", answer, known)
+	}
+
+	submitter, err := submit.NewSubmitter(*baseURL, sessionFile, stateFile)
+	if err != nil {
+		return err
+	}
+
+	response, err := submitter.Submit(context.Background(), *year, day.Number, part, answer.String())
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "day %d part %d: answer %v is %v\n", day.Number, part, answer, response.Outcome)
+	if response.Outcome != submit.Correct {
+		fmt.Fprintln(stdout, response.Message)
+		return fmt.Errorf("answer not accepted: %v", response.Outcome)
+	}
+
+	store.Record(key, answer)
+	if err := store.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "recorded answer in %s\n", store.Path())
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+
+	"github.com/lo-b/aoc24/internal/answers"
+	"github.com/lo-b/aoc24/internal/profiling"
+	"github.com/lo-b/aoc24/internal/report"
+)
+
+// verifyCmd re-runs registered days and compares their answers with the known
+// answers. A day that cannot be solved, e.g. for a missing input or a panic,
+// is reported as an ERROR row without stopping the other days.
+func verifyCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("verify", stderr)
+	answersPath := flags.String("answers", answers.DefaultPath(), "path to the known answers file")
+	record := flags.Bool("record", false, "record unknown answers as known answers")
+	format := formatFlag(flags)
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	days, err := selectDays(positional, "")
+	if err != nil {
+		return err
+	}
+
+	store, err := answers.Load(*answersPath)
+	if err != nil {
+		return err
+	}
+
+	var failed, errored, recorded int
+	table := report.NewTable("status", "day", "part", "input", "answer", "expected", "error")
+	for _, run := range solveDays(days, nil, profiling.Options{}, "", []int{1, 2}, runtime.GOMAXPROCS(0), 0) {
+		day := days[run.Index]
+		if run.Err != nil {
+			errored++
+			table.Append("ERROR", day.Number, nil, nil, nil, nil, run.Err.Error())
+			continue
+		}
+
+		result := run.Value
+		for _, part := range result.Parts {
+			key := answers.Key{Day: day.Number, Part: part.Part, Input: result.Input}
+			status, known := store.Check(key, part.Answer)
+
+			var expected any
+			switch status {
+			case answers.Pass:
+				expected = known
+			case answers.Fail:
+				failed++
+				expected = known
+			case answers.Unknown:
+				if *record {
+					store.Record(key, part.Answer)
+					recorded++
+				}
+			}
+
+			table.Append(status.String(), day.Number, part.Part, result.Input, part.Answer, expected, nil)
+		}
+	}
+
+	if err := report.Write(stdout, *format, table); err != nil {
+		return err
+	}
+
+	if recorded > 0 {
+		if err := store.Save(); err != nil {
+			return err
+		}
+		fmt.Fprintf(stderr, "recorded %d answers in %s\n", recorded, store.Path())
+	}
+
+	var errs []error
+	if errored > 0 {
+		errs = append(errs, fmt.Errorf("%d of %d days failed", errored, len(days)))
+	}
+	if failed > 0 {
+		errs = append(errs, fmt.Errorf("%d answers differ from the known answers in %s", failed, store.Path()))
+	}
+
+	return errors.Join(errs...)
+}
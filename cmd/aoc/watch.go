@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/modroot"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/report"
+	"github.com/lo-b/aoc24/internal/solution"
+	"github.com/lo-b/aoc24/internal/testutil"
+	"github.com/lo-b/aoc24/internal/watch"
+)
+
+// watchCmd rebuilds and re-runs a day whenever its sources, tests or input
+// change.
+func watchCmd(args []string, stdout io.Writer, stderr io.Writer) error {
+	flags := newFlagSet("watch", stderr)
+	inputPath := flags.String("input", "", "path to puzzle input")
+	interval := flags.Duration("interval", 500*time.Millisecond, "time between polls for changes")
+	once := flags.Bool("once", false, "build, test and run once instead of watching for changes")
+	options := registerDayOptions(flags)
+
+	positional, err := parseArgs(flags, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) != 1 {
+		return errors.New("expected a single day number or slug")
+	}
+
+	if *interval <= 0 {
+		return fmt.Errorf("--interval %v: expected a positive duration", *interval)
+	}
+
+	if *inputPath == puzzleio.StdinPath {
+		return errors.New("cannot watch stdin, pass a file to --input")
+	}
+
+	day, err := lookupDay(positional[0])
+	if err != nil {
+		return err
+	}
+
+	if day.Package == "" {
+		return fmt.Errorf("day %d has no package to build and test", day.Number)
+	}
+
+	if err := options.validate([]registry.Day{day}); err != nil {
+		return err
+	}
+
+	root, err := modroot.Find(".")
+	if err != nil {
+		return err
+	}
+
+	input, err := resolveInputPath(day, *inputPath)
+	if err != nil {
+		return err
+	}
+
+	binDir, err := os.MkdirTemp("", "aoc-watch-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(binDir)
+
+	w := &watcher{
+		root:    root,
+		day:     day,
+		input:   input,
+		options: options.args(flags, day),
+		binary:  filepath.Join(binDir, "aoc"),
+		out:     stdout,
+		answers: make(map[int]solution.Answer),
+	}
+
+	if *once {
+		return w.cycle(nil)
+	}
+
+	// NOTE: the shared packages every day imports are watched too, as are the
+	// golden files its tests read, see package testutil
+	paths := []string{
+		filepath.Join(root, "internal", "days", day.Package),
+		filepath.Join(root, "cmd", day.Slug, "solution"),
+		testutil.Dir(root, day.Slug),
+		filepath.Join(root, "internal", "puzzleio"),
+		filepath.Join(root, "internal", "solution"),
+		input,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	w.cycle(nil)
+	fmt.Fprintf(stdout, "watching day %d (%s) for changes, press Ctrl+C to stop\n", day.Number, day.Slug)
+
+	err = watch.Poll(ctx, paths, *interval, func(changed []string) {
+		w.cycle(changed)
+	})
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+
+	return err
+}
+
+// resolveInputPath returns the absolute path of the input of day, so the
+// rebuilt runner reads the same file wherever it resolves inputs from.
+func resolveInputPath(day registry.Day, inputPath string) (string, error) {
+	puzzleInput, _, err := puzzleio.Resolve(inputPath, day.Input)
+	if err != nil {
+		return "", fmt.Errorf("day %d: %w", day.Number, err)
+	}
+	puzzleInput.Close()
+
+	return filepath.Abs(puzzleInput.Path)
+}
+
+// watcher rebuilds and re-runs a single day, remembering the answers and
+// timing of the previous run.
+type watcher struct {
+	root    string
+	day     registry.Day
+	input   string
+	options []string // day options passed on to the runner
+	binary  string
+	out     io.Writer
+
+	answers map[int]solution.Answer
+	elapsed time.Duration
+}
+
+// cycle rebuilds the runner, runs the example tests of the day and solves its
+// input, printing the answers next to the previous ones. It returns the first
+// step that failed; failing tests do not stop the input from being solved.
+func (w *watcher) cycle(changed []string) error {
+	fmt.Fprintf(w.out, "\n[%s]", time.Now().Format(time.TimeOnly))
+	for _, path := range changed {
+		if rel, err := filepath.Rel(w.root, path); err == nil && filepath.IsLocal(rel) {
+			path = rel
+		}
+		fmt.Fprintf(w.out, " %s", path)
+	}
+	fmt.Fprintln(w.out)
+
+	if output, err := w.goCmd("build", "-o", w.binary, "./cmd/aoc"); err != nil {
+		fmt.Fprintf(w.out, "build: FAIL\n%s", output)
+		return fmt.Errorf("build: %w", err)
+	}
+	fmt.Fprintln(w.out, "build: ok")
+
+	testErr := w.test()
+
+	answers, elapsed, err := w.run()
+	if err != nil {
+		return err
+	}
+
+	table := report.NewTable("part", "previous", "answer", "changed")
+	for _, part := range []int{1, 2} {
+		previous, seen := w.answers[part]
+
+		var previousCell, changedCell any
+		if seen {
+			previousCell = previous
+			if previous != answers[part] {
+				changedCell = "*"
+			}
+		}
+
+		table.Append(part, previousCell, answers[part], changedCell)
+	}
+	if err := report.Write(w.out, report.Text, table); err != nil {
+		return err
+	}
+
+	// NOTE: the time of the runner process, so it includes its startup and
+	// reading the input; use the bench command to time the solution itself
+	if w.elapsed > 0 {
+		fmt.Fprintf(w.out, "run time: %v (previous %v, including process startup)\n", elapsed.Round(time.Microsecond), w.elapsed.Round(time.Microsecond))
+	} else {
+		fmt.Fprintf(w.out, "run time: %v (including process startup)\n", elapsed.Round(time.Microsecond))
+	}
+
+	w.answers, w.elapsed = answers, elapsed
+	return testErr
+}
+
+// test runs the tests of the day's package, which hold the site examples.
+func (w *watcher) test() error {
+	output, err := w.goCmd("test", "./internal/days/"+w.day.Package)
+	if err != nil {
+		fmt.Fprintf(w.out, "tests: FAIL\n%s", output)
+		return fmt.Errorf("tests: %w", err)
+	}
+
+	fmt.Fprintln(w.out, "tests: ok")
+	return nil
+}
+
+// run solves the input with the rebuilt runner and returns the answers by
+// part and the wall time of the runner process.
+func (w *watcher) run() (map[int]solution.Answer, time.Duration, error) {
+	var stdout, stderr bytes.Buffer
+	args := append([]string{"run", strconv.Itoa(w.day.Number), "--input", w.input, "--format", string(report.JSONLines)}, w.options...)
+	cmd := exec.Command(w.binary, args...)
+	cmd.Dir = w.root
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	elapsed := time.Since(start)
+	if err != nil {
+		fmt.Fprintf(w.out, "run: FAIL\n%s", stderr.String())
+		return nil, 0, fmt.Errorf("run: %w", err)
+	}
+
+	answers := make(map[int]solution.Answer)
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		var row struct {
+			Part   int             `json:"part"`
+			Answer solution.Answer `json:"answer"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			return nil, 0, fmt.Errorf("run: unexpected output %q: %w", scanner.Text(), err)
+		}
+
+		answers[row.Part] = row.Answer
+	}
+
+	return answers, elapsed, scanner.Err()
+}
+
+// goCmd runs the go tool with args in the module root and returns its
+// combined output.
+func (w *watcher) goCmd(args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = w.root
+
+	return cmd.CombinedOutput()
+}
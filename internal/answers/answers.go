@@ -0,0 +1,166 @@
+// Package answers stores the known answers of the calendar, keyed by day,
+// part and the fingerprint of the input that produced them, so a refactor
+// cannot silently change an answer.
+package answers
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/lo-b/aoc24/internal/modroot"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+const (
+	// FileEnv names the environment variable overriding the answers file.
+	FileEnv = "AOC_ANSWERS_FILE"
+	// DefaultFile is the name of the answers file in the repository root.
+	DefaultFile = "answers.json"
+)
+
+// Key identifies the answer to a part of a day for a specific input.
+type Key struct {
+	Day   int                  `json:"day"`
+	Part  int                  `json:"part"`
+	Input puzzleio.Fingerprint `json:"input"`
+}
+
+// Entry is a known answer as stored in the answers file.
+type Entry struct {
+	Key
+	Answer solution.Answer `json:"answer"`
+}
+
+// Store holds the known answers read from, and written back to, a JSON file.
+type Store struct {
+	path    string
+	answers map[Key]solution.Answer
+}
+
+// Load reads the answers file at path. A missing file results in an empty
+// store, which is created on Save.
+func Load(path string) (*Store, error) {
+	store := &Store{path: path, answers: make(map[Key]solution.Answer)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read answers: %w", err)
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("unable to read answers %s: %w", path, err)
+	}
+
+	for _, entry := range entries {
+		store.answers[entry.Key] = entry.Answer
+	}
+
+	return store, nil
+}
+
+// DefaultPath returns the path of the answers file: the value of
+// AOC_ANSWERS_FILE, or DefaultFile in the module root, see modroot.Find.
+func DefaultPath() string {
+	if path := os.Getenv(FileEnv); path != "" {
+		return path
+	}
+
+	root, err := modroot.Find(".")
+	if err != nil {
+		return DefaultFile
+	}
+
+	return filepath.Join(root, DefaultFile)
+}
+
+// Path returns the path of the answers file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Lookup returns the known answer for key, or false if it is unknown.
+func (s *Store) Lookup(key Key) (solution.Answer, bool) {
+	answer, ok := s.answers[key]
+	return answer, ok
+}
+
+// Record stores answer as the known answer for key, replacing any previous
+// one.
+func (s *Store) Record(key Key, answer solution.Answer) {
+	s.answers[key] = answer
+}
+
+// Save writes all answers to the answers file, ordered by day, part and input
+// so the file diffs cleanly.
+func (s *Store) Save() error {
+	entries := make([]Entry, 0, len(s.answers))
+	for key, answer := range s.answers {
+		entries = append(entries, Entry{Key: key, Answer: answer})
+	}
+
+	slices.SortFunc(entries, func(a, b Entry) int {
+		return cmp.Or(
+			cmp.Compare(a.Day, b.Day),
+			cmp.Compare(a.Part, b.Part),
+			cmp.Compare(a.Input.String(), b.Input.String()),
+		)
+	})
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(s.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("unable to write answers: %w", err)
+	}
+
+	return nil
+}
+
+// Status is the outcome of checking an answer against the known answers.
+type Status int
+
+const (
+	Unknown Status = iota // no known answer for the key
+	Pass                  // answer equals the known answer
+	Fail                  // answer differs from the known answer
+)
+
+func (s Status) String() string {
+	switch s {
+	case Unknown:
+		return "UNKNOWN"
+	case Pass:
+		return "PASS"
+	case Fail:
+		return "FAIL"
+	}
+
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+// Check compares answer with the known answer for key, which is returned as
+// well when there is one.
+func (s *Store) Check(key Key, answer solution.Answer) (Status, solution.Answer) {
+	known, ok := s.Lookup(key)
+	if !ok {
+		return Unknown, solution.Answer{}
+	}
+
+	if known != answer {
+		return Fail, known
+	}
+
+	return Pass, known
+}
@@ -0,0 +1,83 @@
+package answers_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/answers"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+func fingerprint(t *testing.T, content string) puzzleio.Fingerprint {
+	t.Helper()
+
+	fingerprint, err := puzzleio.FingerprintOf(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return fingerprint
+}
+
+func TestStore_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	store, err := answers.Load(path)
+	if err != nil {
+		t.Fatalf("expected missing answers file to load as empty store, got %v", err)
+	}
+
+	siteExample := answers.Key{Day: 1, Part: 1, Input: fingerprint(t, "3   4\n")}
+	textual := answers.Key{Day: 14, Part: 2, Input: fingerprint(t, "p=0,4 v=3,-3\n")}
+	store.Record(siteExample, solution.Int(11))
+	store.Record(textual, solution.Text("6,0"))
+
+	if err := store.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	loaded, err := answers.Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for key, want := range map[answers.Key]solution.Answer{siteExample: solution.Int(11), textual: solution.Text("6,0")} {
+		if got, ok := loaded.Lookup(key); !ok || got != want {
+			t.Errorf("Lookup(%+v): got %v (%v), want %v", key, got, ok, want)
+		}
+	}
+}
+
+func TestStore_Check(t *testing.T) {
+	store, err := answers.Load(filepath.Join(t.TempDir(), "answers.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := answers.Key{Day: 2, Part: 1, Input: fingerprint(t, "7 6 4 2 1\n")}
+	store.Record(key, solution.Int(559))
+
+	tests := []struct {
+		name       string
+		key        answers.Key
+		answer     solution.Answer
+		wantStatus answers.Status
+	}{
+		{"known answer matches", key, solution.Int(559), answers.Pass},
+		{"known answer differs", key, solution.Int(560), answers.Fail},
+		{"same answer as text differs", key, solution.Text("559"), answers.Fail},
+		{"other input is unknown", answers.Key{Day: 2, Part: 1}, solution.Int(559), answers.Unknown},
+		{"other part is unknown", answers.Key{Day: 2, Part: 2, Input: key.Input}, solution.Int(559), answers.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, _ := store.Check(tt.key, tt.answer)
+			if status != tt.wantStatus {
+				t.Errorf("got %v, want %v", status, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+// Package bench measures the wall time and allocations of repeated runs of a
+// function, e.g. the parse step or a part of a solution.
+package bench
+
+import (
+	"errors"
+	"math"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// Stats summarises the runs of a measured function.
+type Stats struct {
+	Runs        int
+	Min         time.Duration
+	Median      time.Duration
+	P95         time.Duration
+	AllocsPerOp uint64 // mean number of heap allocations per run
+	BytesPerOp  uint64 // mean number of heap allocated bytes per run
+}
+
+// Measure calls fn runs times and returns statistics of the wall time and heap
+// allocations of the calls. Measuring stops at the first error.
+//
+// The setup function, if not nil, is called before every run and is excluded
+// from the measurements; e.g. to create a fresh solution to parse into.
+func Measure(runs int, setup func() error, fn func() error) (Stats, error) {
+	if runs < 1 {
+		return Stats{}, errors.New("bench: runs must be at least 1")
+	}
+
+	var (
+		durations          = make([]time.Duration, 0, runs)
+		mallocs, allocated uint64
+		before, after      runtime.MemStats
+	)
+
+	for range runs {
+		if setup != nil {
+			if err := setup(); err != nil {
+				return Stats{}, err
+			}
+		}
+
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		err := fn()
+		elapsed := time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if err != nil {
+			return Stats{}, err
+		}
+
+		durations = append(durations, elapsed)
+		mallocs += after.Mallocs - before.Mallocs
+		allocated += after.TotalAlloc - before.TotalAlloc
+	}
+
+	slices.Sort(durations)
+
+	return Stats{
+		Runs:        runs,
+		Min:         durations[0],
+		Median:      Median(durations),
+		P95:         Percentile(durations, 95),
+		AllocsPerOp: mallocs / uint64(runs),
+		BytesPerOp:  allocated / uint64(runs),
+	}, nil
+}
+
+// Median returns the median of the sorted, non-empty durations.
+func Median(sorted []time.Duration) time.Duration {
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+
+	return sorted[mid]
+}
+
+// Percentile returns the p-th percentile of the sorted, non-empty durations,
+// using the nearest-rank method.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = max(1, min(rank, len(sorted)))
+
+	return sorted[rank-1]
+}
@@ -0,0 +1,93 @@
+package bench_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/bench"
+)
+
+func TestPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 20; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+
+	tests := []struct {
+		name string
+		p    float64
+		want time.Duration
+	}{
+		{"p95 of 20 runs", 95, 19 * time.Millisecond},
+		{"p50 of 20 runs", 50, 10 * time.Millisecond},
+		{"p100 is the maximum", 100, 20 * time.Millisecond},
+		{"p0 is the minimum", 0, 1 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bench.Percentile(sorted, tt.p); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		sorted []time.Duration
+		want   time.Duration
+	}{
+		{"odd number of runs", []time.Duration{1, 2, 9}, 2},
+		{"even number of runs", []time.Duration{1, 2, 4, 9}, 3},
+		{"single run", []time.Duration{5}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bench.Median(tt.sorted); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var sink []byte
+
+func TestMeasure(t *testing.T) {
+	var setups, calls int
+	stats, err := bench.Measure(5, func() error {
+		setups++
+		return nil
+	}, func() error {
+		calls++
+		sink = make([]byte, 1<<16)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if setups != 5 || calls != 5 || stats.Runs != 5 {
+		t.Errorf("expected 5 setups, calls and runs, got %d, %d and %d", setups, calls, stats.Runs)
+	}
+
+	if stats.Min > stats.Median || stats.Median > stats.P95 {
+		t.Errorf("expected min <= median <= p95, got %v, %v, %v", stats.Min, stats.Median, stats.P95)
+	}
+
+	if stats.BytesPerOp < 1<<16 {
+		t.Errorf("expected at least %d bytes allocated per run, got %d", 1<<16, stats.BytesPerOp)
+	}
+
+	wantErr := errors.New("index out of range")
+	if _, err := bench.Measure(3, nil, func() error { return wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("got error %v, want %v", err, wantErr)
+	}
+
+	if _, err := bench.Measure(0, nil, func() error { return nil }); err == nil {
+		t.Error("expected error for zero runs")
+	}
+}
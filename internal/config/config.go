@@ -0,0 +1,191 @@
+// Package config sets flags that are not given on the command line from
+// environment variables and a JSON config file, so every command can be
+// configured the same way in scripts and CI.
+//
+// A flag named name of a command scoped scope is looked up, in order of
+// precedence, as:
+//
+//  1. the command line flag --name
+//  2. the environment variables AOC_<SCOPE>_<NAME> and AOC_<NAME>
+//  3. the config file keys "<scope>.<name>" and "<name>"
+//
+// Environment variable names are upper case with dashes and dots replaced by
+// underscores. The config file holds a JSON object whose nested objects are
+// flattened using dots, so {"run": {"format": "csv"}} sets "run.format".
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lo-b/aoc24/internal/modroot"
+)
+
+const (
+	// FileEnv names the environment variable overriding the config file.
+	FileEnv = "AOC_CONFIG"
+	// DefaultFile is the name of the config file in the repository root.
+	DefaultFile = "aoc.json"
+	// EnvPrefix prefixes the environment variables of all flags.
+	EnvPrefix = "AOC_"
+	// PathFlag is the name of the flag holding the path of the config file.
+	PathFlag = "config"
+)
+
+// File holds the flattened values of a config file.
+type File map[string]string
+
+// Load reads the config file at path. A missing file results in an empty
+// File.
+func Load(path string) (File, error) {
+	file := make(File)
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return file, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read config: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var values map[string]any
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("unable to read config %s: %w", path, err)
+	}
+
+	if err := file.flatten("", values); err != nil {
+		return nil, fmt.Errorf("unable to read config %s: %w", path, err)
+	}
+
+	return file, nil
+}
+
+// flatten adds values to f, prefixing their keys with prefix.
+func (f File) flatten(prefix string, values map[string]any) error {
+	for key, value := range values {
+		switch value := value.(type) {
+		case map[string]any:
+			if err := f.flatten(prefix+key+".", value); err != nil {
+				return err
+			}
+		case []any, nil:
+			return fmt.Errorf("%s%s: expected a string, number or boolean", prefix, key)
+		default:
+			f[prefix+key] = fmt.Sprint(value)
+		}
+	}
+
+	return nil
+}
+
+// DefaultPath returns the path of the config file: the value of AOC_CONFIG,
+// or DefaultFile in the module root, see modroot.Find.
+func DefaultPath() string {
+	if path := os.Getenv(FileEnv); path != "" {
+		return path
+	}
+
+	root, err := modroot.Find(".")
+	if err != nil {
+		return DefaultFile
+	}
+
+	return filepath.Join(root, DefaultFile)
+}
+
+// EnvName returns the environment variable of the flag or key name, e.g.
+// AOC_CERES_SEARCH_WORD for ceres-search.word.
+func EnvName(name string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// Register adds the --config flag and a usage message describing the lookup
+// order to flags. The scope is the name of the command, which qualifies
+// environment variables and config file keys.
+func Register(flags *flag.FlagSet, scope string) {
+	flags.String(PathFlag, DefaultPath(), "path to the JSON config file")
+	flags.Usage = func() {
+		out := flags.Output()
+		fmt.Fprintf(out, "Usage of %s:\n", flags.Name())
+		flags.PrintDefaults()
+		fmt.Fprintf(out, "\nFlags not given on the command line are read from the environment variables\n"+
+			"%s or %s, or the config file keys %q or %q.\n",
+			EnvName(scope+".<flag>"), EnvName("<flag>"), scope+".<flag>", "<flag>")
+	}
+}
+
+// Apply sets every flag of flags that was not given on the command line from
+// the environment or the config file named by the --config flag, see the
+// package documentation. Values are validated by the flags themselves.
+func Apply(flags *flag.FlagSet, scope string) error {
+	path := DefaultPath()
+	if pathFlag := flags.Lookup(PathFlag); pathFlag != nil {
+		path = pathFlag.Value.String()
+	}
+
+	file, err := Load(path)
+	if err != nil {
+		return err
+	}
+
+	return file.Apply(flags, scope)
+}
+
+// Apply sets every flag of flags that was not given on the command line from
+// the environment or f.
+func (f File) Apply(flags *flag.FlagSet, scope string) error {
+	given := make(map[string]bool)
+	flags.Visit(func(fl *flag.Flag) {
+		given[fl.Name] = true
+	})
+
+	var errs []error
+	flags.VisitAll(func(fl *flag.Flag) {
+		if given[fl.Name] || fl.Name == PathFlag {
+			return
+		}
+
+		source, value, ok := f.lookup(fl.Name, scope)
+		if !ok {
+			return
+		}
+
+		if err := flags.Set(fl.Name, value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value %q for flag -%s from %s: %w", value, fl.Name, source, err))
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// lookup returns the value of the flag name, and where it was found, from
+// the environment or f.
+func (f File) lookup(name string, scope string) (string, string, bool) {
+	names := []string{name}
+	if scope != "" {
+		names = []string{scope + "." + name, name}
+	}
+
+	for _, name := range names {
+		if value, ok := os.LookupEnv(EnvName(name)); ok {
+			return EnvName(name), value, true
+		}
+	}
+
+	for _, name := range names {
+		if value, ok := f[name]; ok {
+			return fmt.Sprintf("config key %q", name), value, true
+		}
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,169 @@
+package config_test
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/config"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "aoc.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeConfig(t, `{"format": "csv", "run": {"part": 2, "verbose": true}, "ceres-search": {"word": "SAMX"}}`)
+
+	file, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := config.File{"format": "csv", "run.part": "2", "run.verbose": "true", "ceres-search.word": "SAMX"}
+	if len(file) != len(want) {
+		t.Errorf("got %v, want %v", file, want)
+	}
+	for key, value := range want {
+		if file[key] != value {
+			t.Errorf("%s: got %q, want %q", key, file[key], value)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"not an object", `["csv"]`},
+		{"array value", `{"run": {"parts": [1, 2]}}`},
+		{"null value", `{"format": null}`},
+		{"malformed", `{"format": "csv"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := config.Load(writeConfig(t, tt.content)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+
+	file, err := config.Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || len(file) != 0 {
+		t.Errorf("got %v (error: %v), want an empty config for a missing file", file, err)
+	}
+}
+
+func TestApply(t *testing.T) {
+	file := config.File{
+		"format":            "csv",
+		"run.part":          "1",
+		"runs":              "5",
+		"ceres-search.word": "SAMX",
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want map[string]string
+	}{
+		{
+			name: "config file",
+			want: map[string]string{"format": "csv", "part": "1", "runs": "5", "ceres-search.word": "SAMX"},
+		},
+		{
+			name: "environment overrides config file",
+			env:  map[string]string{"AOC_FORMAT": "json", "AOC_RUN_RUNS": "7", "AOC_CERES_SEARCH_WORD": "MAS"},
+			want: map[string]string{"format": "json", "part": "1", "runs": "7", "ceres-search.word": "MAS"},
+		},
+		{
+			name: "scoped environment overrides unscoped",
+			env:  map[string]string{"AOC_PART": "2", "AOC_RUN_PART": "0"},
+			want: map[string]string{"part": "0"},
+		},
+		{
+			name: "command line overrides environment",
+			args: []string{"--format", "text", "--part", "2"},
+			env:  map[string]string{"AOC_FORMAT": "json", "AOC_RUN_PART": "1"},
+			want: map[string]string{"format": "text", "part": "2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			flags := flag.NewFlagSet("run", flag.ContinueOnError)
+			flags.String("format", "text", "")
+			flags.Int("part", 0, "")
+			flags.Int("runs", 10, "")
+			flags.String("ceres-search.word", "XMAS", "")
+
+			if err := flags.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := file.Apply(flags, "run"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for name, want := range tt.want {
+				if got := flags.Lookup(name).Value.String(); got != want {
+					t.Errorf("%s: got %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestApply_InvalidValue(t *testing.T) {
+	t.Setenv("AOC_RUNS", "many")
+
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.Int("runs", 10, "")
+
+	if err := (config.File{}).Apply(flags, "bench"); err == nil {
+		t.Error("expected error applying invalid value")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	path := writeConfig(t, `{"bench": {"runs": 3}}`)
+
+	flags := flag.NewFlagSet("aoc bench", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	config.Register(flags, "bench")
+	runs := flags.Int("runs", 10, "")
+
+	if err := flags.Parse([]string{"--config", path}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := config.Apply(flags, "bench"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if *runs != 3 {
+		t.Errorf("got %d, want 3", *runs)
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	if got, want := config.EnvName("red-nosed-reports.max-level-diff"), "AOC_RED_NOSED_REPORTS_MAX_LEVEL_DIFF"; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,342 @@
+// Package ceressearch solves day 4 of the calendar: finding 'XMAS' in a word
+// search.
+package ceressearch
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// Direction represents a compass direction as an enumerated integer type.
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+func init() {
+	registry.Register(registry.Day{
+		Number:  4,
+		Slug:    "ceres-search",
+		Input:   "puzzle.txt",
+		Package: "ceressearch",
+		New:     func() solution.Solution { return &Solution{Options: DefaultOptions} },
+		Options: func() solution.Options {
+			options := DefaultOptions
+			return &options
+		},
+	})
+}
+
+// Options are the runtime options of the solution.
+type Options struct {
+	Word string // word to find in the first part
+}
+
+// DefaultOptions are the options given by the puzzle.
+var DefaultOptions = Options{Word: "XMAS"}
+
+// Flags implements solution.Options.
+func (o *Options) Flags(flags *flag.FlagSet, prefix string) {
+	flags.StringVar(&o.Word, prefix+"word", o.Word, "word to find in the word search")
+}
+
+// Validate implements solution.Options.
+func (o *Options) Validate() error {
+	if o.Word == "" {
+		return errors.New("word must not be empty")
+	}
+
+	// NOTE: the word search compares bytes, so only ASCII letters are found
+	for _, letter := range o.Word {
+		if letter <= ' ' || letter > '~' {
+			return fmt.Errorf("word %q must only contain printable ASCII characters", o.Word)
+		}
+	}
+
+	return nil
+}
+
+// New implements solution.Options.
+func (o *Options) New() solution.Solution {
+	return &Solution{Options: *o}
+}
+
+// Solution holds the parsed word search and the word to find in it.
+type Solution struct {
+	Options Options
+	puzzle  *puzzleio.Grid
+}
+
+// Parse reads the word search grid from input.
+func (s *Solution) Parse(input io.Reader) error {
+	puzzle, err := puzzleio.ReadGrid(input)
+	if err != nil {
+		return err
+	}
+
+	s.puzzle = puzzle
+	return nil
+}
+
+// Part1 returns the number of times the word, 'XMAS' by default, occurs in the
+// word search.
+func (s *Solution) Part1() (solution.Answer, error) {
+	return solution.Int(WordSearch(s.puzzle, s.Options.Word)), nil
+}
+
+// Part2 returns the number of X-MAS shapes in the word search.
+func (s *Solution) Part2() (solution.Answer, error) {
+	return solution.Int(XmasSearch(s.puzzle)), nil
+}
+
+// XmasSearch finds the 'MAS' words in the shape of an X and returns the sum of total occurences.
+func XmasSearch(puzzle *puzzleio.Grid) int {
+	var totalXmasCount int
+
+	startingPoints := lookupLetter(puzzle, 'A')
+	for _, point := range startingPoints {
+		if validXmas(puzzle, point) {
+			totalXmasCount++
+		}
+	}
+
+	return totalXmasCount
+}
+
+// validXmas returns true if two 'MAS' words with shared 'A' (rune) are in the shape of an X.
+func validXmas(puzzle *puzzleio.Grid, point puzzleio.Point) bool {
+	row, col := point.Row, point.Col
+
+	if row-1 < 0 || row+1 >= puzzle.Height {
+		return false
+	}
+
+	if col-1 < 0 || col+1 >= puzzle.Width {
+		return false
+	}
+
+	if !xShaped(puzzle, point) {
+		return false
+	}
+
+	return true
+}
+
+// xShaped returns true if 'MAS' words (forwards & backward) make an X shape.
+func xShaped(puzzle *puzzleio.Grid, point puzzleio.Point) bool {
+	const word = "MAS"
+	var wordCount int
+
+	// loop over all possible diagonal directions (NE, SE, SW, NW)
+	for d := 1; d <= 7; d += 2 {
+		p := offSetPoint(Direction(d), point)
+		diag := getDiag(Direction(d), p, puzzle, word)
+		if string(diag) == word {
+			wordCount++
+		}
+
+		if wordCount == 2 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// offSetPoint returns a new point, offsetting point according to specified direction.
+func offSetPoint(direction Direction, point puzzleio.Point) puzzleio.Point {
+	row, col := point.Row, point.Col
+	if direction == NorthEast {
+		return puzzleio.Point{Row: row + 1, Col: col - 1}
+	} else if direction == SouthEast {
+		return puzzleio.Point{Row: row - 1, Col: col - 1}
+	} else if direction == SouthWest {
+		return puzzleio.Point{Row: row - 1, Col: col + 1}
+	} else if direction == NorthWest {
+		return puzzleio.Point{Row: row + 1, Col: col + 1}
+	}
+
+	return point
+}
+
+// WordSearch looks in puzzle for occurrences of word and returns the sum of times word appears in the puzzle.
+func WordSearch(puzzle *puzzleio.Grid, word string) int {
+	var totalWordCount int
+	wordStartPoints := lookupLetter(puzzle, rune(word[0]))
+
+	for _, point := range wordStartPoints {
+		totalWordCount += walkPaths(point, word, puzzle)
+	}
+
+	return totalWordCount
+}
+
+// lookupLetter returns a list of points where letter occurs in puzzle.
+func lookupLetter(puzzle *puzzleio.Grid, letter rune) []puzzleio.Point {
+	var points []puzzleio.Point
+	for point := range puzzle.Points() {
+		if puzzle.At(point) == letter {
+			points = append(points, point)
+		}
+	}
+
+	return points
+}
+
+// walkPaths tries to walk all possible path directions and return the sum of paths containing the word to search for.
+func walkPaths(point puzzleio.Point, word string, puzzle *puzzleio.Grid) int {
+	var (
+		validWordCount int
+		blockLen       = len(word) - 1
+		row, col       = point.Row, point.Col
+	)
+
+	if row-blockLen >= 0 && walk(North, point, puzzle, word) {
+		validWordCount++
+	}
+
+	if row-blockLen >= 0 &&
+		col+blockLen < puzzle.Width &&
+		walk(NorthEast, point, puzzle, word) {
+		validWordCount++
+	}
+
+	if col+blockLen < puzzle.Width && walk(East, point, puzzle, word) {
+		validWordCount++
+	}
+
+	if row+blockLen < puzzle.Height &&
+		col+blockLen < puzzle.Width &&
+		walk(SouthEast, point, puzzle, word) {
+		validWordCount++
+	}
+
+	if row+blockLen < puzzle.Height && walk(South, point, puzzle, word) {
+		validWordCount++
+	}
+
+	if col-blockLen >= 0 && row+blockLen < puzzle.Height &&
+		walk(SouthWest, point, puzzle, word) {
+		validWordCount++
+	}
+
+	if col-blockLen >= 0 && walk(West, point, puzzle, word) {
+		validWordCount++
+	}
+
+	if col-blockLen >= 0 && row-blockLen >= 0 &&
+		walk(NorthWest, point, puzzle, word) {
+		validWordCount++
+	}
+
+	return validWordCount
+}
+
+// walk 'traverses' a path by getting the path as a rune slice and return true if
+// the slice contains word, false otherwise.
+func walk(direction Direction, point puzzleio.Point, puzzle *puzzleio.Grid, word string) bool {
+	if slice := pathSlice(direction, point, puzzle, word); string(slice) != word {
+		return false
+	}
+
+	return true
+}
+
+// pathSlice constructs a slice of runes from a 'path', starting at point, going in the specified direction and having
+// length equal to word.
+func pathSlice(direction Direction, point puzzleio.Point, puzzle *puzzleio.Grid, word string) []rune {
+	if direction == East || direction == West {
+		return getRow(direction, point, puzzle, word)
+	}
+
+	if direction == North || direction == South {
+		return getCol(direction, point, puzzle, word)
+	}
+
+	return getDiag(direction, point, puzzle, word)
+}
+
+// getDiag returns a diagonal as a rune slice. It is created by starting from a point in the puzzle, going into
+// direction and has a length equal to the word length.
+func getDiag(direction Direction, point puzzleio.Point, puzzle *puzzleio.Grid, word string) []rune {
+	var diag []rune
+	var x, y = point.Row, point.Col
+	if direction == NorthEast {
+		for i := 0; i < len(word); i++ {
+			diag = append(diag, puzzle.At(puzzleio.Point{Row: x - i, Col: y + i}))
+		}
+	} else if direction == SouthEast {
+		for i := 0; i < len(word); i++ {
+			diag = append(diag, puzzle.At(puzzleio.Point{Row: x + i, Col: y + i}))
+		}
+	} else if direction == SouthWest {
+		for i := 0; i < len(word); i++ {
+			diag = append(diag, puzzle.At(puzzleio.Point{Row: x + i, Col: y - i}))
+		}
+	} else if direction == NorthWest {
+		for i := 0; i < len(word); i++ {
+			diag = append(diag, puzzle.At(puzzleio.Point{Row: x - i, Col: y - i}))
+		}
+	} else {
+		err := fmt.Errorf("invalid direction: %v", direction)
+		println(err)
+	}
+
+	return diag
+}
+
+// getRow returns a row as a rune slice. The row is created by starting from a point in the puzzle, going into direction
+// and has a length equal to the word length.
+func getRow(direction Direction, point puzzleio.Point, puzzle *puzzleio.Grid, word string) []rune {
+	var row []rune
+	var x, y = point.Row, point.Col
+	if direction == East {
+		for i := 0; i < len(word); i++ {
+			row = append(row, puzzle.At(puzzleio.Point{Row: x, Col: y + i}))
+		}
+	} else if direction == West {
+		for i := 0; i < len(word); i++ {
+			row = append(row, puzzle.At(puzzleio.Point{Row: x, Col: y - i}))
+		}
+	} else {
+		err := fmt.Errorf("invalid direction: %v", direction)
+		println(err)
+	}
+
+	return row
+}
+
+// getCol returns a column as a rune slice. The column is created by starting from a point in the puzzle, going into
+// direction and has a length equal to the word length.
+func getCol(direction Direction, point puzzleio.Point, puzzle *puzzleio.Grid, word string) []rune {
+	var col []rune
+	var x, y = point.Row, point.Col
+	if direction == South {
+		for i := 0; i < len(word); i++ {
+			col = append(col, puzzle.At(puzzleio.Point{Row: x + i, Col: y}))
+		}
+	} else if direction == North {
+		for i := 0; i < len(word); i++ {
+			col = append(col, puzzle.At(puzzleio.Point{Row: x - i, Col: y}))
+		}
+	} else {
+		err := fmt.Errorf("unexpected direction: %v", direction)
+		println(err)
+	}
+
+	return col
+}
@@ -1,6 +1,12 @@
-package main
+package ceressearch
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/testutil"
+)
 
 const word = "XMAS"
 
@@ -71,7 +77,7 @@ func TestWordSearch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := WordSearch(CreateRuneGrid(tt.input), word)
+			actual := WordSearch(mustGrid(t, tt.input), word)
 			if actual != tt.expected {
 				t.Errorf("Expected puzzle input\n%+v\nto contain %d occurrences of %s, found %d\n", tt.input, tt.expected, word, actual)
 			}
@@ -123,10 +129,48 @@ func TestXmasSearch(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			actual := XmasSearch(CreateRuneGrid(tt.input))
+			actual := XmasSearch(mustGrid(t, tt.input))
 			if actual != tt.expected {
 				t.Errorf("Expected puzzle input\n%v to contain %d occurrences of X-mas, found %d\n.", tt.input, tt.expected, actual)
 			}
 		})
 	}
 }
+
+// mustGrid creates a puzzleio.Grid from rows, failing the test on error.
+func mustGrid(t *testing.T, rows []string) *puzzleio.Grid {
+	t.Helper()
+
+	grid, err := puzzleio.NewGrid(rows)
+	if err != nil {
+		t.Fatalf("invalid puzzle input: %v", err)
+	}
+
+	return grid
+}
+
+func TestSolution(t *testing.T) {
+	testutil.Golden(t, "ceres-search")
+}
+
+func TestOptions(t *testing.T) {
+	const siteExample = "MMMSXXMASM\nMSAMXMSMSA\nAMXSXMAAMM\nMSAMASMSMX\nXMASAMXAMM\nXXAMMXXAMA\nSMSMSASXSS\nSAXAMASAAA\nMAMMMXMMMM\nMXMXAXMASX\n"
+
+	sol := (&Options{Word: "SAMX"}).New()
+	if err := sol.Parse(strings.NewReader(siteExample)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// NOTE: every XMAS read backwards is a SAMX
+	part1, err := sol.Part1()
+	if err != nil || part1.String() != "18" {
+		t.Errorf("part 1: got %v (error: %v), want 18", part1, err)
+	}
+
+	for _, word := range []string{"", "X MAS", "ÄMAS"} {
+		options := Options{Word: word}
+		if err := options.Validate(); err == nil {
+			t.Errorf("expected error validating word %q", word)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package historianhysteria solves day 1 of the calendar: reconciling the
+// left and right lists of location ids.
+package historianhysteria
+
+import (
+	"io"
+	"math"
+	"sort"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+func init() {
+	registry.Register(registry.Day{
+		Number:  1,
+		Slug:    "historian-hysteria",
+		Input:   "location_ids.txt",
+		Package: "historianhysteria",
+		New:     func() solution.Solution { return &Solution{} },
+	})
+}
+
+// Solution holds the parsed left and right location id lists.
+type Solution struct {
+	left  []int
+	right []int
+}
+
+// locationPair is a single line of input: a left and right location id.
+type locationPair struct {
+	Left  int
+	Right int
+}
+
+var pairPattern = puzzleio.MustCompileRegexp(`^\s*(\S+)\s+(\S+)\s*$`)
+
+// Parse reads the left and right location id lists from input.
+func (s *Solution) Parse(input io.Reader) error {
+	left, right, err := ParseLocationIDs(input)
+	if err != nil {
+		return err
+	}
+
+	s.left, s.right = left, right
+	return nil
+}
+
+// Part1 returns the total distance between the lists.
+func (s *Solution) Part1() (solution.Answer, error) {
+	return solution.Int(TotalDistance(s.left, s.right)), nil
+}
+
+// Part2 returns the total similarity score of the lists.
+func (s *Solution) Part2() (solution.Answer, error) {
+	return solution.Int(TotalSimilarityScore(s.left, s.right)), nil
+}
+
+// ParseLocationIDs reads the left and right columns of location ids. Every line
+// must contain exactly one pair of ids.
+func ParseLocationIDs(input io.Reader) ([]int, []int, error) {
+	// left and right contain all location ids read of left and right col resp.
+	var left, right []int
+	for pair, err := range puzzleio.DecodeLines[locationPair](input, pairPattern) {
+		if err != nil {
+			return nil, nil, err
+		}
+
+		left = append(left, pair.Value.Left)
+		right = append(right, pair.Value.Right)
+	}
+
+	return left, right, nil
+}
+
+// TotalDistance calculates the sum of distancess between smallest pairs in
+// left and right arrays.
+func TotalDistance(left []int, right []int) int {
+	leftSorted, rightSorted := make([]int, len(left)), make([]int, len(right))
+
+	copy(leftSorted, left)
+	copy(rightSorted, right)
+
+	sort.Ints(leftSorted)
+	sort.Ints(rightSorted)
+
+	total := 0
+	for idx, val := range leftSorted {
+		total += int(math.Abs(float64(val - rightSorted[idx])))
+	}
+
+	return total
+}
+
+// TotalSimilarityScore calculates the total similarity score by summing values
+// in the left list, each multiplied by the number of times it appears in the
+// right list.
+func TotalSimilarityScore(left []int, right []int) int {
+	rightNumCounts := make(map[int]int)
+	for _, num := range right {
+		rightNumCounts[num] = rightNumCounts[num] + 1
+	}
+
+	total := 0
+	for _, val := range left {
+		total += val * rightNumCounts[val]
+	}
+
+	return total
+}
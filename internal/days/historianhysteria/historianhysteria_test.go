@@ -1,7 +1,9 @@
-package main
+package historianhysteria
 
 import (
 	"testing"
+
+	"github.com/lo-b/aoc24/internal/testutil"
 )
 
 func TestTotalDistance(t *testing.T) {
@@ -49,3 +51,7 @@ func TestTotalSimilarityScore(t *testing.T) {
 		})
 	}
 }
+
+func TestSolution(t *testing.T) {
+	testutil.Golden(t, "historian-hysteria")
+}
@@ -1,7 +1,9 @@
-package main
+package mullitover
 
 import (
 	"testing"
+
+	"github.com/lo-b/aoc24/internal/testutil"
 )
 
 func TestParse(t *testing.T) {
@@ -97,3 +99,7 @@ func TestExtendedMul(t *testing.T) {
 		})
 	}
 }
+
+func TestSolution(t *testing.T) {
+	testutil.Golden(t, "mull-it-over")
+}
@@ -0,0 +1,223 @@
+// Package rednosedreports solves day 2 of the calendar: counting the safe
+// reports of the reactor levels.
+package rednosedreports
+
+import (
+	"cmp"
+	"errors"
+	"flag"
+	"io"
+	"math"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+const (
+	MinLevelDif  = 1 // default minimum difference of adjacent levels.
+	MaxLevelDiff = 3 // default maximum adjacent level difference.
+)
+
+func init() {
+	registry.Register(registry.Day{
+		Number:  2,
+		Slug:    "red-nosed-reports",
+		Input:   "reports.txt",
+		Package: "rednosedreports",
+		New:     func() solution.Solution { return &Solution{Options: DefaultOptions} },
+		Options: func() solution.Options {
+			options := DefaultOptions
+			return &options
+		},
+	})
+}
+
+// Options are the runtime options of the solution.
+type Options struct {
+	MinLevelDiff int // minimum difference of adjacent levels
+	MaxLevelDiff int // maximum difference of adjacent levels
+}
+
+// DefaultOptions are the options given by the puzzle.
+var DefaultOptions = Options{MinLevelDiff: MinLevelDif, MaxLevelDiff: MaxLevelDiff}
+
+// Flags implements solution.Options.
+func (o *Options) Flags(flags *flag.FlagSet, prefix string) {
+	flags.IntVar(&o.MinLevelDiff, prefix+"min-level-diff", o.MinLevelDiff, "minimum difference of adjacent levels in a safe report")
+	flags.IntVar(&o.MaxLevelDiff, prefix+"max-level-diff", o.MaxLevelDiff, "maximum difference of adjacent levels in a safe report")
+}
+
+// Validate implements solution.Options.
+func (o *Options) Validate() error {
+	if o.MinLevelDiff < 0 {
+		return errors.New("min-level-diff must not be negative")
+	}
+
+	if o.MaxLevelDiff < o.MinLevelDiff {
+		return errors.New("max-level-diff must not be less than min-level-diff")
+	}
+
+	return nil
+}
+
+// New implements solution.Options.
+func (o *Options) New() solution.Solution {
+	return &Solution{Options: *o}
+}
+
+// Solution holds the parsed reports and the options to validate them with.
+type Solution struct {
+	Options Options
+	reports [][]int
+}
+
+// Parse reads the reports from input.
+func (s *Solution) Parse(input io.Reader) error {
+	reports, err := ParseReports(input)
+	if err != nil {
+		return err
+	}
+
+	s.reports = reports
+	return nil
+}
+
+// Part1 returns the number of valid reports.
+func (s *Solution) Part1() (solution.Answer, error) {
+	return solution.Int(s.Options.CountValidReports(s.reports, false)), nil
+}
+
+// Part2 returns the number of valid reports when the problem dampener
+// tolerates a single bad level.
+func (s *Solution) Part2() (solution.Answer, error) {
+	return solution.Int(s.Options.CountValidReports(s.reports, true)), nil
+}
+
+// ParseReports reads a report, i.e. a list of levels, from every line of input.
+func ParseReports(input io.Reader) ([][]int, error) {
+	var reports [][]int
+	for row, err := range puzzleio.IntRows(input) {
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, row.Value)
+	}
+
+	return reports, nil
+}
+
+// CountValidReports returns the number of valid reports using the default
+// options, optionally tolerating a single bad level per report.
+func CountValidReports(reports [][]int, useTolerance bool) int {
+	return DefaultOptions.CountValidReports(reports, useTolerance)
+}
+
+// CountValidReports returns the number of valid reports, optionally tolerating
+// a single bad level per report.
+func (o Options) CountValidReports(reports [][]int, useTolerance bool) int {
+	var validReportCount = 0
+	for _, levels := range reports {
+		if useTolerance && o.validWithDampener(levels) {
+			validReportCount++
+		} else if !useTolerance {
+			report := createReport(levels, o.MinLevelDiff, o.MaxLevelDiff)
+			if report.isValid() {
+				validReportCount++
+			}
+		}
+	}
+
+	return validReportCount
+}
+
+// validWithDampener checks if levels are valid according to the following
+// criteria:
+//   - levels are either all increasing or all decreasing.
+//   - two adjacent levels differ by at least MinLevelDiff and at most
+//     MaxLevelDiff.
+//   - tolerate a single bad level in what would otherwise be a safe report.
+func (o Options) validWithDampener(levels []int) bool {
+	for k := 0; k < len(levels); k++ {
+		var slicedLevels []int
+		slicedLevels = append(slicedLevels, levels[:k]...)
+		slicedLevels = append(slicedLevels, levels[k+1:]...)
+
+		report := createReport(slicedLevels, o.MinLevelDiff, o.MaxLevelDiff)
+		if report.isValid() {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Report struct {
+	levels    []int
+	validator Validator
+}
+
+type Validator interface {
+	// check returns true if the integer pair x and y is valid, false
+	// otherwise.
+	check(x int, y int) bool
+}
+
+type LevelValidator struct {
+	sign int
+	min  int
+	max  int
+}
+
+func createReport(levels []int, min int, max int) Report {
+	// NOTE: a report without adjacent levels has no direction and no pair to
+	// violate it, so it is safe
+	var sign int
+	if len(levels) > 1 {
+		sign = cmp.Compare(levels[0], levels[1])
+	}
+
+	var report Report
+	report.levels = levels
+	report.validator = LevelValidator{sign, min, max}
+
+	return report
+}
+
+// isValid checks if a Report is valid, according to the criteria:
+//   - levels are either all increasing or all decreasing.
+//   - two adjacent levels differ by at least one and at most three.
+func (r Report) isValid() bool {
+	validator, levels := r.validator, r.levels
+
+	for levelIdx := range levels {
+		hasNext := levelIdx <= len(levels)-2
+		if hasNext {
+			invalidPair := !validator.check(levels[levelIdx], levels[levelIdx+1])
+			if invalidPair {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// check determines whether levels x and y are valid, according to the
+// following criteria:
+//   - absolute difference of x and y is in the range [1,3]
+//   - comparing x and y yields a sign that continues (previous)
+//     ascending/descending order
+func (validator LevelValidator) check(x int, y int) bool {
+
+	newSign := cmp.Compare(x, y)
+	var adjacentLevelDiff = int(math.Abs(float64(x - y)))
+	if newSign != validator.sign ||
+		adjacentLevelDiff < validator.min ||
+		adjacentLevelDiff > validator.max {
+		return false
+	}
+
+	return true
+}
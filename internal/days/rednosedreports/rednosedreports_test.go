@@ -1,7 +1,10 @@
-package main
+package rednosedreports
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/lo-b/aoc24/internal/testutil"
 )
 
 func TestLevelValidatorCheck(t *testing.T) {
@@ -156,6 +159,11 @@ func TestValidReportCheck(t *testing.T) {
 			[]int{3, 6, 3},
 			false,
 		},
+		{
+			"SingleLevel_ReturnTrue",
+			[]int{5},
+			true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -168,3 +176,65 @@ func TestValidReportCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestCountValidReports(t *testing.T) {
+	var tests = []struct {
+		name         string
+		reports      [][]int
+		useTolerance bool
+		want         int
+	}{
+		{"SingleLevel_IsValid", [][]int{{5}}, false, 1},
+		{"SingleLevel_WithDampener_IsValid", [][]int{{5}}, true, 1},
+		{"TwoLevels_OutOfBounds_IsInvalid", [][]int{{1, 9}}, false, 0},
+		{"TwoLevels_DampenedToSingleLevel_IsValid", [][]int{{1, 9}}, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountValidReports(tt.reports, tt.useTolerance); got != tt.want {
+				t.Errorf("expected %v but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSolution(t *testing.T) {
+	testutil.Golden(t, "red-nosed-reports")
+}
+
+func TestOptions(t *testing.T) {
+	const siteExample = "7 6 4 2 1\n1 2 7 8 9\n9 7 6 2 1\n1 3 2 4 5\n8 6 4 4 1\n1 3 6 7 9\n"
+
+	var tests = []struct {
+		name    string
+		options Options
+		part1   string
+		part2   string
+	}{
+		{"default options", DefaultOptions, "2", "4"},
+		{"wider max level diff", Options{MinLevelDiff: 1, MaxLevelDiff: 5}, "4", "6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sol := tt.options.New()
+			if err := sol.Parse(strings.NewReader(siteExample)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			part1, _ := sol.Part1()
+			part2, _ := sol.Part2()
+			if part1.String() != tt.part1 || part2.String() != tt.part2 {
+				t.Errorf("got %v and %v, want %v and %v", part1, part2, tt.part1, tt.part2)
+			}
+		})
+	}
+
+	invalid := []Options{{MinLevelDiff: -1, MaxLevelDiff: 3}, {MinLevelDiff: 3, MaxLevelDiff: 1}}
+	for _, options := range invalid {
+		if err := options.Validate(); err == nil {
+			t.Errorf("expected error validating %+v", options)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package leaderboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+// DefaultMaxAge is how long a fetched leaderboard is reused before it is
+// fetched again; the AoC automation guidelines ask to request a private
+// leaderboard at most once every 15 minutes.
+const DefaultMaxAge = 15 * time.Minute
+
+// Fetcher downloads private leaderboards from an AoC-compatible HTTP endpoint
+// and caches them on disk for MaxAge.
+type Fetcher struct {
+	BaseURL   string        // endpoint, e.g. puzzleio.DefaultBaseURL
+	Session   string        // value of the 'session' cookie
+	CacheDir  string        // directory leaderboards are cached in
+	UserAgent string        // User-Agent header sent with every request
+	MaxAge    time.Duration // time a cached leaderboard is used for
+	Client    *http.Client
+	Now       func() time.Time // current time, time.Now when nil
+}
+
+// NewFetcher creates a Fetcher for baseURL, using the session token stored in
+// sessionFile and caching leaderboards in cacheDir.
+func NewFetcher(baseURL string, sessionFile string, cacheDir string) (*Fetcher, error) {
+	session, err := puzzleio.ReadSession(sessionFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fetcher{
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		Session:   session,
+		CacheDir:  cacheDir,
+		UserAgent: puzzleio.DefaultUserAgent,
+		MaxAge:    DefaultMaxAge,
+		Client:    http.DefaultClient,
+	}, nil
+}
+
+// CachePath returns the path the leaderboard id of year is cached at.
+func (f *Fetcher) CachePath(year int, id string) string {
+	return filepath.Join(f.CacheDir, fmt.Sprint(year), fmt.Sprintf("leaderboard-%s.json", id))
+}
+
+// Fetch returns the private leaderboard id of year, from the cache when it was
+// fetched less than MaxAge ago.
+func (f *Fetcher) Fetch(ctx context.Context, year int, id string) (*Leaderboard, error) {
+	path := f.CachePath(year, id)
+	if info, err := os.Stat(path); err == nil && f.now().Sub(info.ModTime()) < f.MaxAge {
+		return Load(path)
+	}
+
+	body, err := f.download(ctx, year, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: an expired session is redirected to a page that is not JSON; decode
+	// before caching so it is never cached
+	leaderboard, err := Decode(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("unable to cache leaderboard: %w", err)
+	}
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return nil, fmt.Errorf("unable to cache leaderboard: %w", err)
+	}
+
+	return leaderboard, nil
+}
+
+// download requests the leaderboard id of year from the endpoint.
+func (f *Fetcher) download(ctx context.Context, year int, id string) ([]byte, error) {
+	url := fmt.Sprintf("%s/%d/leaderboard/private/view/%s.json", f.BaseURL, year, id)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", f.UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch leaderboard: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch leaderboard: %s returned %s", url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch leaderboard: %w", err)
+	}
+
+	return body, nil
+}
+
+func (f *Fetcher) now() time.Time {
+	if f.Now == nil {
+		return time.Now()
+	}
+
+	return f.Now()
+}
@@ -0,0 +1,96 @@
+// Package leaderboard reads the JSON export of a private leaderboard and
+// computes the statistics of its members: the time each star took, the time
+// between the two stars of a day, local scores and streaks.
+package leaderboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Leaderboard is the JSON export of a private leaderboard.
+type Leaderboard struct {
+	Event   string            `json:"event"` // year of the calendar
+	OwnerID int               `json:"owner_id"`
+	Members map[string]Member `json:"members"` // keyed by member ID
+}
+
+// Member is a member of a leaderboard and the stars they collected.
+type Member struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"` // empty for anonymous members
+	Stars      int    `json:"stars"`
+	LocalScore int    `json:"local_score"`
+	LastStarTS int64  `json:"last_star_ts"`
+	// CompletionDayLevel holds the stars keyed by day and part, e.g.
+	// CompletionDayLevel["1"]["2"] is the second star of the first day.
+	CompletionDayLevel map[string]map[string]Star `json:"completion_day_level"`
+}
+
+// Star is a collected star.
+type Star struct {
+	GetStarTS int64 `json:"get_star_ts"` // Unix time the star was collected
+	StarIndex int   `json:"star_index"`  // order of the star across all members
+}
+
+// DisplayName returns the name of m, or the name the leaderboard shows for an
+// anonymous member.
+func (m Member) DisplayName() string {
+	if m.Name == "" {
+		return fmt.Sprintf("(anonymous user #%d)", m.ID)
+	}
+
+	return m.Name
+}
+
+// Star returns the star of m for part of day, or false when m does not have
+// it.
+func (m Member) Star(day int, part int) (Star, bool) {
+	star, ok := m.CompletionDayLevel[strconv.Itoa(day)][strconv.Itoa(part)]
+	return star, ok
+}
+
+// Year returns the year of the calendar of l.
+func (l *Leaderboard) Year() (int, error) {
+	year, err := strconv.Atoi(l.Event)
+	if err != nil {
+		return 0, fmt.Errorf("invalid leaderboard event %q", l.Event)
+	}
+
+	return year, nil
+}
+
+// Decode reads a leaderboard export from r.
+func Decode(r io.Reader) (*Leaderboard, error) {
+	var leaderboard Leaderboard
+	if err := json.NewDecoder(r).Decode(&leaderboard); err != nil {
+		return nil, fmt.Errorf("unable to decode leaderboard: %w", err)
+	}
+
+	if _, err := leaderboard.Year(); err != nil {
+		return nil, err
+	}
+
+	return &leaderboard, nil
+}
+
+// Load reads a leaderboard export from the file at path.
+func Load(path string) (*Leaderboard, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Decode(file)
+}
+
+// Unlock returns the time the puzzle of day is unlocked, midnight in the
+// US Eastern time zone, which is UTC-5 in December.
+func Unlock(year int, day int) time.Time {
+	return time.Date(year, time.December, day, 5, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,183 @@
+package leaderboard_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/leaderboard"
+)
+
+// export is a leaderboard of three members over the first three days of 2024,
+// which unlock at 1733029200, 1733115600 and 1733202000.
+const export = `{
+  "event": "2024",
+  "owner_id": 1,
+  "members": {
+    "1": {"id": 1, "name": "alice", "stars": 5, "local_score": 14, "last_star_ts": 1733202300,
+      "completion_day_level": {
+        "1": {"1": {"get_star_ts": 1733029800, "star_index": 2}, "2": {"get_star_ts": 1733030100, "star_index": 3}},
+        "2": {"1": {"get_star_ts": 1733116800, "star_index": 5}, "2": {"get_star_ts": 1733119200, "star_index": 6}},
+        "3": {"1": {"get_star_ts": 1733202300, "star_index": 8}}
+      }},
+    "2": {"id": 2, "name": "bob", "stars": 4, "local_score": 10, "last_star_ts": 1733203200,
+      "completion_day_level": {
+        "1": {"1": {"get_star_ts": 1733029500, "star_index": 1}, "2": {"get_star_ts": 1733031000, "star_index": 4}},
+        "3": {"1": {"get_star_ts": 1733202600, "star_index": 9}, "2": {"get_star_ts": 1733203200, "star_index": 10}}
+      }},
+    "3": {"id": 3, "name": null, "stars": 1, "local_score": 2, "last_star_ts": 1733122800,
+      "completion_day_level": {
+        "2": {"1": {"get_star_ts": 1733122800, "star_index": 7}}
+      }}
+  }
+}`
+
+func TestStandings(t *testing.T) {
+	board, err := leaderboard.Decode(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	standings, err := leaderboard.Standings(board, time.Date(2024, time.December, 3, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var tests = []struct {
+		name          string
+		score         int
+		stars         int
+		longestStreak int
+		currentStreak int
+	}{
+		{"alice", 14, 5, 2, 2},
+		{"bob", 10, 4, 1, 1},
+		{"(anonymous user #3)", 2, 1, 0, 0},
+	}
+
+	if len(standings) != len(tests) {
+		t.Fatalf("got %d standings, want %d", len(standings), len(tests))
+	}
+
+	for idx, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			standing := standings[idx]
+			if got := standing.Member.DisplayName(); got != tt.name {
+				t.Errorf("got member %v, want %v", got, tt.name)
+			}
+			if standing.LocalScore != tt.score || standing.LocalScore != standing.Member.LocalScore {
+				t.Errorf("got score %d, want %d", standing.LocalScore, tt.score)
+			}
+			if standing.Stars != tt.stars {
+				t.Errorf("got %d stars, want %d", standing.Stars, tt.stars)
+			}
+			if standing.LongestStreak != tt.longestStreak || standing.CurrentStreak != tt.currentStreak {
+				t.Errorf("got streaks %d/%d, want %d/%d", standing.LongestStreak, standing.CurrentStreak, tt.longestStreak, tt.currentStreak)
+			}
+		})
+	}
+
+	day, ok := standings[0].Day(2)
+	want := leaderboard.DayResult{Day: 2, Part1: 20 * time.Minute, Part2: time.Hour, Delta: 40 * time.Minute, Points: 6}
+	if !ok || day != want {
+		t.Errorf("got %+v, want %+v", day, want)
+	}
+
+	if _, ok := standings[1].Day(2); ok {
+		t.Error("expected no result for a day without stars")
+	}
+}
+
+func TestStandings_StreakBrokenAfterMissedDay(t *testing.T) {
+	board, err := leaderboard.Decode(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	standings, err := leaderboard.Standings(board, time.Date(2024, time.December, 5, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, standing := range standings {
+		if standing.CurrentStreak != 0 {
+			t.Errorf("%s: got current streak %d, want 0", standing.Member.DisplayName(), standing.CurrentStreak)
+		}
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	for _, input := range []string{"<html>log in</html>", `{"event": "next year"}`} {
+		if _, err := leaderboard.Decode(strings.NewReader(input)); err == nil {
+			t.Errorf("expected error decoding %q", input)
+		}
+	}
+}
+
+func TestFetch_CachesLeaderboard(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Redirect(w, r, "/2024/auth/login", http.StatusFound)
+			return
+		}
+
+		if r.URL.Path != "/2024/leaderboard/private/view/1.json" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(export))
+	}))
+	t.Cleanup(server.Close)
+
+	sessionFile := filepath.Join(t.TempDir(), "session")
+	if err := os.WriteFile(sessionFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fetcher, err := leaderboard.NewFetcher(server.URL, sessionFile, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Now()
+	fetcher.Now = func() time.Time { return now }
+
+	for range 2 {
+		board, err := fetcher.Fetch(context.Background(), 2024, "1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(board.Members) != 3 {
+			t.Errorf("got %d members, want 3", len(board.Members))
+		}
+	}
+	if hits.Load() != 1 {
+		t.Errorf("expected leaderboard to be fetched once, got %d requests", hits.Load())
+	}
+
+	now = now.Add(leaderboard.DefaultMaxAge)
+	if _, err := fetcher.Fetch(context.Background(), 2024, "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hits.Load() != 2 {
+		t.Errorf("expected expired leaderboard to be fetched again, got %d requests", hits.Load())
+	}
+
+	fetcher.Session = "expired"
+	if _, err := fetcher.Fetch(context.Background(), 2024, "2"); err == nil {
+		t.Error("expected error for a rejected session")
+	}
+	if _, err := os.Stat(fetcher.CachePath(2024, "2")); err == nil {
+		t.Error("expected failed fetch not to be cached")
+	}
+}
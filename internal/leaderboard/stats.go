@@ -0,0 +1,186 @@
+package leaderboard
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Days is the number of days of the calendar.
+const Days = 25
+
+// DayResult holds the stars of a member on a single day.
+type DayResult struct {
+	Day    int
+	Part1  time.Duration // time from unlock to the first star, zero without it
+	Part2  time.Duration // time from unlock to the second star, zero without it
+	Delta  time.Duration // time from the first to the second star
+	Points int           // local score earned on the day
+}
+
+// Complete returns true if both stars of the day were collected.
+func (r DayResult) Complete() bool {
+	return r.Part1 > 0 && r.Part2 > 0
+}
+
+// Standing holds the statistics of a member.
+type Standing struct {
+	Member     Member
+	Stars      int
+	LocalScore int
+	// Days holds the days on which the member collected a star, in order.
+	Days []DayResult
+	// LongestStreak is the longest run of consecutive days on which the member
+	// collected both stars.
+	LongestStreak int
+	// CurrentStreak is the run of consecutive days with both stars up to the
+	// last unlocked day; the last day does not break it before it is solved.
+	CurrentStreak int
+}
+
+// Day returns the result of s for day, or false when s has no stars that day.
+func (s Standing) Day(day int) (DayResult, bool) {
+	idx, ok := slices.BinarySearchFunc(s.Days, day, func(r DayResult, day int) int {
+		return cmp.Compare(r.Day, day)
+	})
+	if !ok {
+		return DayResult{}, false
+	}
+
+	return s.Days[idx], true
+}
+
+// Standings computes the statistics of every member of l at now, ordered by
+// local score, then stars, then name.
+//
+// The local score follows the leaderboard: of N members, the first to collect
+// a star earns N points, the second N-1 and so on.
+func Standings(l *Leaderboard, now time.Time) ([]Standing, error) {
+	year, err := l.Year()
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]Standing, 0, len(l.Members))
+	for _, member := range l.Members {
+		standings = append(standings, Standing{Member: member})
+	}
+	slices.SortFunc(standings, func(a, b Standing) int {
+		return cmp.Compare(a.Member.ID, b.Member.ID)
+	})
+
+	for day := 1; day <= Days; day++ {
+		unlock := Unlock(year, day)
+		results := make([]DayResult, len(standings))
+		var collected bool
+
+		for part := 1; part <= 2; part++ {
+			var ranked []int
+			for idx, standing := range standings {
+				if _, ok := standing.Member.Star(day, part); ok {
+					ranked = append(ranked, idx)
+				}
+			}
+
+			slices.SortFunc(ranked, func(a, b int) int {
+				starA, _ := standings[a].Member.Star(day, part)
+				starB, _ := standings[b].Member.Star(day, part)
+				return cmp.Or(cmp.Compare(starA.GetStarTS, starB.GetStarTS), cmp.Compare(starA.StarIndex, starB.StarIndex))
+			})
+
+			for rank, idx := range ranked {
+				star, _ := standings[idx].Member.Star(day, part)
+				elapsed := time.Unix(star.GetStarTS, 0).Sub(unlock)
+
+				result := &results[idx]
+				result.Day = day
+				result.Points += len(standings) - rank
+				if part == 1 {
+					result.Part1 = elapsed
+				} else {
+					result.Part2 = elapsed
+				}
+				collected = true
+			}
+		}
+
+		if !collected {
+			continue
+		}
+
+		for idx := range standings {
+			result := results[idx]
+			if result.Day == 0 {
+				continue
+			}
+
+			if result.Complete() {
+				result.Delta = result.Part2 - result.Part1
+			}
+
+			standing := &standings[idx]
+			standing.Days = append(standing.Days, result)
+			standing.LocalScore += result.Points
+			if result.Part1 > 0 {
+				standing.Stars++
+			}
+			if result.Part2 > 0 {
+				standing.Stars++
+			}
+		}
+	}
+
+	lastDay := unlockedDays(year, now)
+	for idx := range standings {
+		standings[idx].LongestStreak, standings[idx].CurrentStreak = streaks(standings[idx], lastDay)
+	}
+
+	slices.SortStableFunc(standings, func(a, b Standing) int {
+		return cmp.Or(
+			cmp.Compare(b.LocalScore, a.LocalScore),
+			cmp.Compare(b.Stars, a.Stars),
+			cmp.Compare(a.Member.DisplayName(), b.Member.DisplayName()),
+		)
+	})
+
+	return standings, nil
+}
+
+// unlockedDays returns the number of days of year unlocked at now.
+func unlockedDays(year int, now time.Time) int {
+	days := 0
+	for days < Days && !now.Before(Unlock(year, days+1)) {
+		days++
+	}
+
+	return days
+}
+
+// streaks returns the longest and the current run of consecutive complete
+// days of s, where lastDay is the last unlocked day.
+func streaks(s Standing, lastDay int) (longest int, current int) {
+	complete := make([]bool, Days+2)
+	for _, result := range s.Days {
+		complete[result.Day] = result.Complete()
+	}
+
+	run := 0
+	for day := 1; day <= Days; day++ {
+		if complete[day] {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+
+	day := lastDay
+	if day > 0 && !complete[day] {
+		day--
+	}
+	for ; day > 0 && complete[day]; day-- {
+		current++
+	}
+
+	return longest, current
+}
@@ -0,0 +1,29 @@
+// Package modroot locates the root of the Go module the commands and tests
+// run in, which holds the sources and testdata of all days.
+package modroot
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Find returns the first directory, starting at dir and going up, containing a
+// go.mod file.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+
+		if filepath.Dir(dir) == dir {
+			return "", errors.New("no go.mod found in working directory or its parents")
+		}
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,113 @@
+// Package pool runs tasks on a fixed number of workers, isolating each task
+// from the panics and timeouts of the others.
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// PanicError is the error of a task that panicked.
+type PanicError struct {
+	Value any    // value passed to panic
+	Stack []byte // stack of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the value passed to panic if it is an error, e.g. a
+// runtime.Error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Result is the outcome of the task run for the item at Index.
+type Result[R any] struct {
+	Index int
+	Value R
+	Err   error
+}
+
+// Run calls fn for every item using at most workers goroutines and returns
+// the results in the order of items. A panic in fn is recovered and returned
+// as a *PanicError. When timeout is positive, fn is given a context that is
+// cancelled after timeout; a task not returning in time fails with
+// context.DeadlineExceeded and is abandoned, so fn should return as soon as
+// possible once its context is done.
+func Run[T any, R any](ctx context.Context, items []T, workers int, timeout time.Duration, fn func(context.Context, T) (R, error)) []Result[R] {
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Result[R], len(items))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(workers, len(items)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indices {
+				value, err := runTask(ctx, items[idx], timeout, fn)
+				results[idx] = Result[R]{Index: idx, Value: value, Err: err}
+			}
+		}()
+	}
+
+	for idx := range items {
+		indices <- idx
+	}
+	close(indices)
+	wg.Wait()
+
+	return results
+}
+
+// runTask calls fn for item in its own goroutine and waits until it returns,
+// panics or its context is done.
+func runTask[T any, R any](ctx context.Context, item T, timeout time.Duration, fn func(context.Context, T) (R, error)) (R, error) {
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	type outcome struct {
+		value R
+		err   error
+	}
+
+	// NOTE: buffered, so an abandoned task can still finish and exit
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if value := recover(); value != nil {
+				done <- outcome{err: &PanicError{Value: value, Stack: debug.Stack()}}
+			}
+		}()
+
+		value, err := fn(ctx, item)
+		done <- outcome{value: value, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var zero R
+		if timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return zero, fmt.Errorf("timed out after %v: %w", timeout, ctx.Err())
+		}
+
+		return zero, ctx.Err()
+	}
+}
@@ -0,0 +1,86 @@
+package pool_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/pool"
+)
+
+func TestRun(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	var running, maxRunning atomic.Int32
+	results := pool.Run(context.Background(), items, 3, 0, func(_ context.Context, item int) (int, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		return item * item, nil
+	})
+
+	for idx, result := range results {
+		if result.Index != idx || result.Err != nil || result.Value != items[idx]*items[idx] {
+			t.Errorf("got %+v, want value %d at index %d", result, items[idx]*items[idx], idx)
+		}
+	}
+
+	if got := maxRunning.Load(); got > 3 {
+		t.Errorf("got %d concurrent tasks, want at most 3", got)
+	}
+}
+
+func TestRun_Isolation(t *testing.T) {
+	items := []string{"ok", "index", "panic", "slow", "error"}
+	errFailed := errors.New("failed")
+
+	results := pool.Run(context.Background(), items, 2, 50*time.Millisecond, func(ctx context.Context, item string) (string, error) {
+		switch item {
+		case "index":
+			var levels []int
+			return item, fmt.Errorf("level %d", levels[len(item)])
+		case "panic":
+			panic("boom")
+		case "slow":
+			<-ctx.Done()
+			time.Sleep(100 * time.Millisecond)
+		case "error":
+			return "", errFailed
+		}
+
+		return item, nil
+	})
+
+	if results[0].Err != nil || results[0].Value != "ok" {
+		t.Errorf("got %+v, want ok", results[0])
+	}
+
+	var runtimeErr runtime.Error
+	if !errors.As(results[1].Err, &runtimeErr) {
+		t.Errorf("got error %v, want a runtime error", results[1].Err)
+	}
+
+	var panicErr *pool.PanicError
+	if !errors.As(results[2].Err, &panicErr) || panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+		t.Errorf("got error %v, want panic boom with stack", results[2].Err)
+	}
+
+	if !errors.Is(results[3].Err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", results[3].Err, context.DeadlineExceeded)
+	}
+
+	if !errors.Is(results[4].Err, errFailed) {
+		t.Errorf("got error %v, want %v", results[4].Err, errFailed)
+	}
+}
@@ -0,0 +1,149 @@
+// Package profiling writes CPU, heap, block and execution trace profiles
+// around the phases of a solution only, so process startup and reading
+// flags do not show up in them.
+package profiling
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// memProfileRate is the sampling rate of heap profiles while one is running.
+//
+// NOTE: heap profiling is turned off while the package is initialised, i.e.
+// before main runs, so allocations from process startup, reading flags and
+// resolving the input never show up in a heap profile
+var memProfileRate = disableMemProfile()
+
+// disableMemProfile turns off heap profiling and returns the rate it sampled
+// at before.
+func disableMemProfile() int {
+	rate := runtime.MemProfileRate
+	runtime.MemProfileRate = 0
+
+	return rate
+}
+
+// Options holds the files profiles are written to. An empty path disables
+// the profile.
+//
+// All profiles only cover the time between Start and stopping them: the heap
+// profile only samples allocations made in between, its in-use samples show
+// those still live when it is written.
+type Options struct {
+	CPUProfile   string
+	MemProfile   string
+	Trace        string
+	BlockProfile string
+}
+
+// Flags registers the --cpuprofile, --memprofile, --trace and --blockprofile
+// flags on flags.
+func (o *Options) Flags(flags *flag.FlagSet) {
+	flags.StringVar(&o.CPUProfile, "cpuprofile", o.CPUProfile, "write a CPU profile of the solution to `file`")
+	flags.StringVar(&o.MemProfile, "memprofile", o.MemProfile, "write a heap profile of the solution to `file`")
+	flags.StringVar(&o.Trace, "trace", o.Trace, "write an execution trace of the solution to `file`")
+	flags.StringVar(&o.BlockProfile, "blockprofile", o.BlockProfile, "write a goroutine blocking profile of the solution to `file`")
+}
+
+// Enabled returns true if any profile is written.
+func (o Options) Enabled() bool {
+	return o != Options{}
+}
+
+// Start starts the CPU profile, execution trace and block profile and returns
+// a function that stops them and writes all profiles. Profiles are global to
+// the process, so only one may be running at a time.
+func (o Options) Start() (stop func() error, err error) {
+	var stops []func() error
+	stopAll := func() error {
+		var errs []error
+		for _, stop := range stops {
+			errs = append(errs, stop())
+		}
+
+		return errors.Join(errs...)
+	}
+
+	defer func() {
+		if err != nil {
+			stopAll()
+		}
+	}()
+
+	if o.CPUProfile != "" {
+		file, err := os.Create(o.CPUProfile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create CPU profile: %w", err)
+		}
+
+		if err := pprof.StartCPUProfile(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("unable to start CPU profile: %w", err)
+		}
+
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+			return file.Close()
+		})
+	}
+
+	if o.Trace != "" {
+		file, err := os.Create(o.Trace)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create trace: %w", err)
+		}
+
+		if err := trace.Start(file); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("unable to start trace: %w", err)
+		}
+
+		stops = append(stops, func() error {
+			trace.Stop()
+			return file.Close()
+		})
+	}
+
+	if o.BlockProfile != "" {
+		runtime.SetBlockProfileRate(1)
+		stops = append(stops, func() error {
+			defer runtime.SetBlockProfileRate(0)
+			return writeProfile("block", o.BlockProfile)
+		})
+	}
+
+	if o.MemProfile != "" {
+		runtime.MemProfileRate = memProfileRate
+		stops = append(stops, func() error {
+			defer disableMemProfile()
+
+			// NOTE: the GC makes the in-use samples reflect what is still live
+			// after solving
+			runtime.GC()
+			return writeProfile("heap", o.MemProfile)
+		})
+	}
+
+	return stopAll, nil
+}
+
+// writeProfile writes the named runtime/pprof profile to path.
+func writeProfile(name string, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("unable to create %s profile: %w", name, err)
+	}
+
+	if err := pprof.Lookup(name).WriteTo(file, 0); err != nil {
+		file.Close()
+		return fmt.Errorf("unable to write %s profile: %w", name, err)
+	}
+
+	return file.Close()
+}
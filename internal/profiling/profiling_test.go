@@ -0,0 +1,105 @@
+package profiling_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/profiling"
+)
+
+func TestOptions_Start(t *testing.T) {
+	dir := t.TempDir()
+
+	var options profiling.Options
+	flags := flag.NewFlagSet("profile", flag.ContinueOnError)
+	options.Flags(flags)
+
+	err := flags.Parse([]string{
+		"--cpuprofile", filepath.Join(dir, "cpu.pprof"),
+		"--memprofile", filepath.Join(dir, "mem.pprof"),
+		"--trace", filepath.Join(dir, "trace.out"),
+		"--blockprofile", filepath.Join(dir, "block.pprof"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !options.Enabled() {
+		t.Fatal("expected profiling to be enabled")
+	}
+
+	// NOTE: allocations before Start, e.g. reading flags, must not be sampled
+	if runtime.MemProfileRate != 0 {
+		t.Errorf("got memory profile rate %d before Start, want 0", runtime.MemProfileRate)
+	}
+
+	stop, err := options.Start()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runtime.MemProfileRate == 0 {
+		t.Error("expected allocations to be sampled while profiling")
+	}
+
+	var sum int
+	for idx := range 1_000_000 {
+		sum += idx % 7
+	}
+	_ = make([]byte, sum%1024)
+
+	if err := stop(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runtime.MemProfileRate != 0 {
+		t.Errorf("got memory profile rate %d after stopping, want 0", runtime.MemProfileRate)
+	}
+
+	for _, name := range []string{"cpu.pprof", "mem.pprof", "trace.out", "block.pprof"} {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+		} else if info.Size() == 0 {
+			t.Errorf("expected %s not to be empty", name)
+		}
+	}
+}
+
+func TestOptions_StartDisabled(t *testing.T) {
+	var options profiling.Options
+	if options.Enabled() {
+		t.Error("expected profiling to be disabled")
+	}
+
+	stop, err := options.Start()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := stop(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestOptions_StartInvalidPath(t *testing.T) {
+	options := profiling.Options{
+		Trace:      filepath.Join(t.TempDir(), "trace.out"),
+		CPUProfile: filepath.Join(t.TempDir(), "missing", "cpu.pprof"),
+	}
+
+	if _, err := options.Start(); err == nil {
+		t.Fatal("expected error creating profile in a missing directory")
+	}
+
+	// NOTE: a failed start must not leave profiling running
+	options.CPUProfile = ""
+	stop, err := options.Start()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := stop(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
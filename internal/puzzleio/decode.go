@@ -0,0 +1,304 @@
+package puzzleio
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token kinds used as ParseError.Expected by the decoder, next to KindInt.
+const (
+	KindRune    = "single character"
+	KindPattern = "line matching pattern"
+)
+
+// Pattern maps a line of puzzle input onto the fields of a struct. It is
+// either compiled from a scanf-like format, see CompilePattern, or from a
+// regular expression with capture groups, see CompileRegexp.
+//
+// Every capture group fills one exported struct field. Fields tagged with
+// `aoc:"name"` are filled by the named group 'name', all other fields by the
+// groups no tag claims, in declaration order. Fields tagged `aoc:"-"` are skipped.
+//
+// Supported field types are ints, uints, strings and runes (int32), as well as
+// slices and arrays of those. The text captured for a slice or array is split
+// on commas and whitespace first.
+type Pattern struct {
+	re  *regexp.Regexp
+	src string
+}
+
+// verbs maps the verbs of CompilePattern to the capture group they match.
+var verbs = map[byte]string{
+	'd': `([+-]?\d+)`,
+	's': `(\S+)`,
+	'c': `(.)`,
+	'v': `(.*)`,
+}
+
+// CompilePattern compiles a scanf-like format, such as 'p=%d,%d v=%d,%d'. The
+// following verbs are supported:
+//   - %d: a base-ten integer, e.g. '-12'
+//   - %s: a run of non-whitespace characters
+//   - %c: a single character
+//   - %v: the remainder of the line, e.g. a list of numbers
+//   - %%: a literal percent sign
+//
+// All other text must match literally.
+func CompilePattern(format string) (*Pattern, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+
+	for idx := 0; idx < len(format); idx++ {
+		if format[idx] != '%' {
+			start := idx
+			for idx+1 < len(format) && format[idx+1] != '%' {
+				idx++
+			}
+			expr.WriteString(regexp.QuoteMeta(format[start : idx+1]))
+			continue
+		}
+
+		if idx+1 >= len(format) {
+			return nil, fmt.Errorf("invalid pattern %q: trailing %%", format)
+		}
+
+		idx++
+		if format[idx] == '%' {
+			expr.WriteString("%")
+			continue
+		}
+
+		group, ok := verbs[format[idx]]
+		if !ok {
+			return nil, fmt.Errorf("invalid pattern %q: unknown verb %%%c", format, format[idx])
+		}
+		expr.WriteString(group)
+	}
+
+	expr.WriteString("$")
+
+	return &Pattern{re: regexp.MustCompile(expr.String()), src: format}, nil
+}
+
+// CompileRegexp compiles a regular expression whose capture groups fill the
+// struct fields, e.g. `^(?P<op>mul)\((\d+),(\d+)\)$`.
+func CompileRegexp(expr string) (*Pattern, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	return &Pattern{re: re, src: expr}, nil
+}
+
+// MustCompilePattern is like CompilePattern but panics if the format is
+// invalid. Intended for package level variables.
+func MustCompilePattern(format string) *Pattern {
+	p, err := CompilePattern(format)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// MustCompileRegexp is like CompileRegexp but panics if the expression is
+// invalid. Intended for package level variables.
+func MustCompileRegexp(expr string) *Pattern {
+	p, err := CompileRegexp(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// String returns the source the pattern was compiled from.
+func (p *Pattern) String() string {
+	return p.src
+}
+
+// patternCache holds patterns compiled by Decode, keyed by format.
+var patternCache sync.Map
+
+// Decode decodes line onto the struct pointed to by v, using the scanf-like
+// format described by CompilePattern. Compiled formats are cached.
+func Decode(line Line[string], format string, v any) error {
+	cached, ok := patternCache.Load(format)
+	if !ok {
+		p, err := CompilePattern(format)
+		if err != nil {
+			return err
+		}
+		cached, _ = patternCache.LoadOrStore(format, p)
+	}
+
+	return cached.(*Pattern).Decode(line, v)
+}
+
+// DecodeLines decodes every line of r into a new T using p.
+//
+// Iteration stops at the first line that cannot be decoded; a *ParseError is
+// yielded instead of a value.
+func DecodeLines[T any](r io.Reader, p *Pattern) iter.Seq2[Line[T], error] {
+	return func(yield func(Line[T], error) bool) {
+		for line, err := range Lines(r) {
+			if err != nil {
+				yield(Line[T]{Num: line.Num}, err)
+				return
+			}
+
+			var val T
+			if err := p.Decode(line, &val); err != nil {
+				yield(Line[T]{Num: line.Num}, err)
+				return
+			}
+
+			if !yield(Line[T]{Num: line.Num, Value: val}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Decode decodes line onto the struct pointed to by v. A line that does not
+// match the pattern, or a captured token that cannot be converted to its field
+// type, results in a *ParseError.
+func (p *Pattern) Decode(line Line[string], v any) error {
+	ptr := reflect.ValueOf(v)
+	if ptr.Kind() != reflect.Pointer || ptr.IsNil() || ptr.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode: expected non-nil pointer to struct, got %T", v)
+	}
+	dst := ptr.Elem()
+
+	match := p.re.FindStringSubmatchIndex(line.Value)
+	if match == nil {
+		return &ParseError{
+			Line:     line.Num,
+			Column:   1,
+			Token:    line.Value,
+			Expected: fmt.Sprintf("%s %q", KindPattern, p.src),
+		}
+	}
+
+	// NOTE: tags claim their groups first, untagged fields take the
+	// remaining groups in order
+	claimed := make(map[int]bool)
+	for idx := range dst.NumField() {
+		field := dst.Type().Field(idx)
+		if tag := field.Tag.Get("aoc"); field.IsExported() && tag != "" && tag != "-" {
+			group := p.re.SubexpIndex(tag)
+			if group < 0 {
+				return fmt.Errorf("decode: pattern %q has no group named %q", p.src, tag)
+			}
+			claimed[group] = true
+		}
+	}
+
+	var positional []int
+	for group := 1; group <= p.re.NumSubexp(); group++ {
+		if !claimed[group] {
+			positional = append(positional, group)
+		}
+	}
+
+	for idx := range dst.NumField() {
+		field := dst.Type().Field(idx)
+		tag := field.Tag.Get("aoc")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		var group int
+		if tag != "" {
+			group = p.re.SubexpIndex(tag)
+		} else {
+			if len(positional) == 0 {
+				return fmt.Errorf("decode: pattern %q has no group for field %s", p.src, field.Name)
+			}
+			group, positional = positional[0], positional[1:]
+		}
+
+		start, end := match[2*group], match[2*group+1]
+		if start < 0 {
+			// NOTE: optional group did not participate in the match
+			continue
+		}
+
+		if err := setField(dst.Field(idx), line.Value[start:end], line.Num, start+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setField converts token to the type of field and assigns it. Column is the
+// position of token in its line, used in errors.
+func setField(field reflect.Value, token string, line int, column int) error {
+	switch field.Kind() {
+	case reflect.Slice, reflect.Array:
+		elems, columns := splitOffsets(token, isElementSep)
+		if field.Kind() == reflect.Array && len(elems) != field.Len() {
+			return &ParseError{
+				Line:     line,
+				Column:   column,
+				Token:    token,
+				Expected: fmt.Sprintf("%d elements", field.Len()),
+			}
+		}
+
+		if field.Kind() == reflect.Slice {
+			field.Set(reflect.MakeSlice(field.Type(), len(elems), len(elems)))
+		}
+
+		for idx, elem := range elems {
+			if err := setField(field.Index(idx), elem, line, column+columns[idx]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case reflect.String:
+		field.SetString(token)
+		return nil
+	case reflect.Int32:
+		// NOTE: rune is an alias of int32; int32 fields hold a single character
+		char, size := utf8.DecodeRuneInString(token)
+		if size == 0 || size != len(token) {
+			return &ParseError{Line: line, Column: column, Token: token, Expected: KindRune}
+		}
+		field.SetInt(int64(char))
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int64:
+		val, err := strconv.ParseInt(token, 10, field.Type().Bits())
+		if err != nil {
+			return &ParseError{Line: line, Column: column, Token: token, Expected: KindInt, Err: err}
+		}
+		field.SetInt(val)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val, err := strconv.ParseUint(token, 10, field.Type().Bits())
+		if err != nil {
+			return &ParseError{Line: line, Column: column, Token: token, Expected: KindInt, Err: err}
+		}
+		field.SetUint(val)
+		return nil
+	}
+
+	return fmt.Errorf("decode: unsupported field type %s", field.Type())
+}
+
+// isElementSep reports whether char separates the elements of a slice or
+// array token.
+func isElementSep(char rune) bool {
+	return char == ',' || unicode.IsSpace(char)
+}
@@ -0,0 +1,174 @@
+package puzzleio_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+type robot struct {
+	PX, PY int
+	VX, VY int
+}
+
+type instruction struct {
+	Args []int  `aoc:"args"`
+	Op   string `aoc:"op"`
+}
+
+type update struct {
+	Name  string
+	Sign  rune
+	Pages []int
+	Pos   [2]uint8
+}
+
+func TestDecode(t *testing.T) {
+	var got robot
+	err := puzzleio.Decode(puzzleio.Line[string]{Num: 1, Value: "p=0,4 v=3,-3"}, "p=%d,%d v=%d,%d", &got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (robot{PX: 0, PY: 4, VX: 3, VY: -3}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestPattern_DecodeKinds(t *testing.T) {
+	pattern := puzzleio.MustCompilePattern("%s %c: %v @ %s")
+
+	var got update
+	err := pattern.Decode(puzzleio.Line[string]{Num: 1, Value: "upd +: 75,47,61 53 @ 3,4"}, &got)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := update{Name: "upd", Sign: '+', Pages: []int{75, 47, 61, 53}, Pos: [2]uint8{3, 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCompileRegexp_NamedGroups(t *testing.T) {
+	pattern, err := puzzleio.CompileRegexp(`^(?P<op>\w+)\((?P<args>[\d,]*)\)$`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got instruction
+	if err := pattern.Decode(puzzleio.Line[string]{Num: 1, Value: "mul(2,4)"}, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := instruction{Op: "mul", Args: []int{2, 4}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCompileRegexp_MixedGroups(t *testing.T) {
+	pattern := puzzleio.MustCompileRegexp(`^(?P<op>mul)\((\d+),(\d+)\)$`)
+
+	type mul struct {
+		Op   string `aoc:"op"`
+		X, Y int
+	}
+
+	var got mul
+	if err := pattern.Decode(puzzleio.Line[string]{Num: 1, Value: "mul(2,4)"}, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := (mul{Op: "mul", X: 2, Y: 4}); got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	type tooMany struct {
+		Op      string `aoc:"op"`
+		X, Y, Z int
+	}
+	if err := pattern.Decode(puzzleio.Line[string]{Num: 1, Value: "mul(2,4)"}, &tooMany{}); err == nil {
+		t.Error("expected error for a field without a group")
+	}
+}
+
+func TestDecode_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		line       string
+		dst        any
+		wantColumn int
+		wantToken  string
+	}{
+		{
+			name:       "Line not matching pattern",
+			format:     "p=%d,%d v=%d,%d",
+			line:       "q=0,4 v=3,-3",
+			dst:        &robot{},
+			wantColumn: 1,
+			wantToken:  "q=0,4 v=3,-3",
+		},
+		{
+			name:       "Integer overflowing field",
+			format:     "%s %c: %v @ %s",
+			line:       "upd +: 1 @ 3,300",
+			dst:        &update{},
+			wantColumn: 14,
+			wantToken:  "300",
+		},
+		{
+			name:       "Invalid slice element",
+			format:     "%s %c: %v @ %s",
+			line:       "upd +: 75,4x @ 3,4",
+			dst:        &update{},
+			wantColumn: 11,
+			wantToken:  "4x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := puzzleio.Decode(puzzleio.Line[string]{Num: 7, Value: tt.line}, tt.format, tt.dst)
+
+			var parseErr *puzzleio.ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected *ParseError, got %v", err)
+			}
+
+			if parseErr.Line != 7 || parseErr.Column != tt.wantColumn || parseErr.Token != tt.wantToken {
+				t.Errorf(
+					"got %d:%d %q, want 7:%d %q",
+					parseErr.Line, parseErr.Column, parseErr.Token, tt.wantColumn, tt.wantToken,
+				)
+			}
+		})
+	}
+}
+
+func TestDecodeLines(t *testing.T) {
+	pattern := puzzleio.MustCompilePattern("p=%d,%d v=%d,%d")
+
+	var got []robot
+	for line, err := range puzzleio.DecodeLines[robot](strings.NewReader("p=0,4 v=3,-3\np=6,3 v=-1,-3\n"), pattern) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, line.Value)
+	}
+
+	want := []robot{{0, 4, 3, -3}, {6, 3, -1, -3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCompilePattern_InvalidVerb(t *testing.T) {
+	if _, err := puzzleio.CompilePattern("p=%x"); err == nil {
+		t.Error("expected error for unknown verb")
+	}
+}
@@ -0,0 +1,69 @@
+package puzzleio
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Token kinds used as ParseError.Expected by the helpers in this package.
+const (
+	KindInt = "integer"
+)
+
+// ParseError records a token of the puzzle input that could not be
+// interpreted, together with its position.
+type ParseError struct {
+	Path     string // path of the input file, empty when unknown
+	Line     int    // 1-based line number
+	Column   int    // 1-based byte offset within the line
+	Token    string // offending token
+	Expected string // kind of token that was expected, e.g. KindInt
+	Err      error  // underlying error, if any
+
+	relocated bool // line already refers to the whole input, see Section.Relocate
+}
+
+// Error formats the error as 'path:line:col: ...', similar to compiler
+// diagnostics, so editors and terminals can jump to the offending token.
+func (e *ParseError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "<input>"
+	}
+
+	return fmt.Sprintf("%s:%d:%d: expected %s, got %q", path, e.Line, e.Column, e.Expected, e.Token)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseInt converts token to a base-ten int. When conversion fails the returned
+// error is a *ParseError positioned at line and column.
+func ParseInt(token string, line int, column int) (int, error) {
+	val, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, &ParseError{
+			Line:     line,
+			Column:   column,
+			Token:    token,
+			Expected: KindInt,
+			Err:      err,
+		}
+	}
+
+	return val, nil
+}
+
+// WithPath sets the file path of err when it is, or wraps, a *ParseError that
+// has no path yet. Other errors are returned as-is.
+func WithPath(err error, path string) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && parseErr.Path == "" {
+		parseErr.Path = path
+	}
+
+	return err
+}
@@ -0,0 +1,72 @@
+package puzzleio_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+func TestIntRows_ParseErrorPosition(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantLine   int
+		wantColumn int
+		wantToken  string
+	}{
+		{
+			name:       "Invalid first field",
+			input:      "x 2\n",
+			wantLine:   1,
+			wantColumn: 1,
+			wantToken:  "x",
+		},
+		{
+			name:       "Invalid field after multiple spaces",
+			input:      "3   4\n4   3x\n",
+			wantLine:   2,
+			wantColumn: 5,
+			wantToken:  "3x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotErr error
+			for _, err := range puzzleio.IntRows(strings.NewReader(tt.input)) {
+				if err != nil {
+					gotErr = err
+				}
+			}
+
+			var parseErr *puzzleio.ParseError
+			if !errors.As(gotErr, &parseErr) {
+				t.Fatalf("expected *ParseError, got %v", gotErr)
+			}
+
+			if parseErr.Line != tt.wantLine || parseErr.Column != tt.wantColumn || parseErr.Token != tt.wantToken {
+				t.Errorf(
+					"got %d:%d %q, want %d:%d %q",
+					parseErr.Line, parseErr.Column, parseErr.Token,
+					tt.wantLine, tt.wantColumn, tt.wantToken,
+				)
+			}
+
+			if parseErr.Expected != puzzleio.KindInt {
+				t.Errorf("got expected kind %q, want %q", parseErr.Expected, puzzleio.KindInt)
+			}
+		})
+	}
+}
+
+func TestParseErrorWithPath(t *testing.T) {
+	_, err := puzzleio.ParseInt("x", 3, 7)
+	err = puzzleio.WithPath(err, "assets/reports.txt")
+
+	want := `assets/reports.txt:3:7: expected integer, got "x"`
+	if err == nil || err.Error() != want {
+		t.Errorf("got %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,236 @@
+package puzzleio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the endpoint puzzle inputs are fetched from.
+	DefaultBaseURL = "https://adventofcode.com"
+	// DefaultUserAgent identifies the fetcher to the endpoint, as requested by
+	// the AoC automation guidelines.
+	DefaultUserAgent = "github.com/lo-b/aoc24/internal/puzzleio"
+	// DefaultMinInterval is the minimum time between two outgoing requests.
+	DefaultMinInterval = 3 * time.Second
+)
+
+// Fetcher downloads puzzle inputs and pages from an AoC-compatible HTTP
+// endpoint and caches them on disk, keyed by year and day. A file that is
+// present in the cache is never fetched again.
+type Fetcher struct {
+	BaseURL     string        // endpoint, e.g. DefaultBaseURL
+	Session     string        // value of the 'session' cookie
+	CacheDir    string        // directory inputs are cached in
+	UserAgent   string        // User-Agent header sent with every request
+	MinInterval time.Duration // minimum time between two requests
+	Client      *http.Client
+
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+// NewFetcher creates a Fetcher for baseURL, using the session token stored in
+// sessionFile and caching inputs in cacheDir.
+func NewFetcher(baseURL string, sessionFile string, cacheDir string) (*Fetcher, error) {
+	session, err := ReadSession(sessionFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fetcher{
+		BaseURL:     strings.TrimSuffix(baseURL, "/"),
+		Session:     session,
+		CacheDir:    cacheDir,
+		UserAgent:   DefaultUserAgent,
+		MinInterval: DefaultMinInterval,
+		Client:      http.DefaultClient,
+	}, nil
+}
+
+// ReadSession returns the session token stored in sessionFile.
+func ReadSession(sessionFile string) (string, error) {
+	token, err := os.ReadFile(sessionFile)
+	if err != nil {
+		return "", fmt.Errorf("unable to read session token: %w", err)
+	}
+
+	session := strings.TrimSpace(string(token))
+	if session == "" {
+		return "", fmt.Errorf("session token file %s is empty", sessionFile)
+	}
+
+	return session, nil
+}
+
+// DefaultSessionFile returns the file the session token is read from by
+// default, i.e. 'aoc24/session' in the user's config directory.
+func DefaultSessionFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "aoc24", "session"), nil
+}
+
+// DefaultCacheDir returns the directory inputs are cached in by default, i.e.
+// 'aoc24' in the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "aoc24"), nil
+}
+
+// CachePath returns the path the input of year and day is cached at.
+func (f *Fetcher) CachePath(year int, day int) string {
+	return filepath.Join(f.CacheDir, fmt.Sprint(year), fmt.Sprintf("day%02d.txt", day))
+}
+
+// Fetch returns the path of the cached input of year and day, downloading it
+// first when it is not cached yet.
+func (f *Fetcher) Fetch(ctx context.Context, year int, day int) (string, error) {
+	url := fmt.Sprintf("%s/%d/day/%d/input", f.BaseURL, year, day)
+	return f.fetch(ctx, "input", url, f.CachePath(year, day))
+}
+
+// PuzzlePath returns the path the puzzle page of year and day is cached at.
+func (f *Fetcher) PuzzlePath(year int, day int) string {
+	return filepath.Join(f.CacheDir, fmt.Sprint(year), fmt.Sprintf("day%02d.html", day))
+}
+
+// FetchPuzzle returns the path of the cached puzzle page of year and day,
+// downloading it first when it is not cached yet. The page only holds the
+// description of the second part once the first part is solved; remove the
+// cached page to fetch it again.
+func (f *Fetcher) FetchPuzzle(ctx context.Context, year int, day int) (string, error) {
+	url := fmt.Sprintf("%s/%d/day/%d", f.BaseURL, year, day)
+	return f.fetch(ctx, "puzzle", url, f.PuzzlePath(year, day))
+}
+
+// fetch returns path, downloading what from url to it first when it does not
+// exist yet.
+func (f *Fetcher) fetch(ctx context.Context, what string, url string, path string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("unable to read %s cache: %w", what, err)
+	}
+
+	if err := f.throttle(ctx); err != nil {
+		return "", err
+	}
+
+	body, err := f.download(ctx, what, url)
+	if err != nil {
+		return "", err
+	}
+
+	if err := writeFileAtomic(path, body); err != nil {
+		return "", fmt.Errorf("unable to cache %s: %w", what, err)
+	}
+
+	return path, nil
+}
+
+// Open fetches the input of year and day, see Fetch, and opens it.
+func (f *Fetcher) Open(ctx context.Context, year int, day int) (*PuzzleInput, error) {
+	path, err := f.Fetch(ctx, year, day)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPuzzleInput(path)
+}
+
+// throttle blocks until at least MinInterval has passed since the previous
+// request. Must be called with f.mu held.
+func (f *Fetcher) throttle(ctx context.Context) error {
+	wait := time.Until(f.lastRequest.Add(f.MinInterval))
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	f.lastRequest = time.Now()
+	return nil
+}
+
+// download requests what from url.
+func (f *Fetcher) download(ctx context.Context, what string, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", f.UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %s: %w", what, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch %s: %s returned %s", what, url, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch %s: %w", what, err)
+	}
+
+	return body, nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames it,
+// so an interrupted download never leaves a partial input in the cache.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,162 @@
+package puzzleio_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+// newInputServer returns a stand-in for the AoC input endpoint, counting the
+// number of requests it serves.
+func newInputServer(t *testing.T, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{year}/day/{day}/input", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			http.Error(w, "Puzzle inputs differ by user.", http.StatusBadRequest)
+			return
+		}
+
+		if r.UserAgent() != puzzleio.DefaultUserAgent {
+			http.Error(w, "missing user agent", http.StatusForbidden)
+			return
+		}
+
+		w.Write([]byte("input " + r.PathValue("year") + "/" + r.PathValue("day") + "\n"))
+	})
+	mux.HandleFunc("GET /{year}/day/{day}", func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("<article>day " + r.PathValue("day") + "</article>"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestFetcher(t *testing.T, baseURL string, session string) *puzzleio.Fetcher {
+	t.Helper()
+
+	sessionFile := filepath.Join(t.TempDir(), "session")
+	if err := os.WriteFile(sessionFile, []byte(session+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fetcher, err := puzzleio.NewFetcher(baseURL, sessionFile, t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fetcher.MinInterval = 0
+
+	return fetcher
+}
+
+func TestFetch_CachesInput(t *testing.T) {
+	var hits atomic.Int32
+	server := newInputServer(t, &hits)
+	fetcher := newTestFetcher(t, server.URL, "secret")
+
+	for range 3 {
+		path, err := fetcher.Fetch(context.Background(), 2024, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := "input 2024/1\n"; string(data) != want {
+			t.Errorf("got %q, want %q", data, want)
+		}
+	}
+
+	if hits.Load() != 1 {
+		t.Errorf("expected input to be fetched once, got %d requests", hits.Load())
+	}
+}
+
+func TestFetchPuzzle(t *testing.T) {
+	var hits atomic.Int32
+	server := newInputServer(t, &hits)
+	fetcher := newTestFetcher(t, server.URL, "secret")
+
+	for range 2 {
+		path, err := fetcher.FetchPuzzle(context.Background(), 2024, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if path != fetcher.PuzzlePath(2024, 3) {
+			t.Errorf("got %v, want %v", path, fetcher.PuzzlePath(2024, 3))
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := "<article>day 3</article>"; string(data) != want {
+			t.Errorf("got %q, want %q", data, want)
+		}
+	}
+
+	if hits.Load() != 1 {
+		t.Errorf("expected page to be fetched once, got %d requests", hits.Load())
+	}
+}
+
+func TestFetch_BadSession(t *testing.T) {
+	var hits atomic.Int32
+	server := newInputServer(t, &hits)
+	fetcher := newTestFetcher(t, server.URL, "wrong")
+
+	if _, err := fetcher.Fetch(context.Background(), 2024, 2); err == nil {
+		t.Fatal("expected error for rejected session")
+	}
+
+	if _, err := os.Stat(fetcher.CachePath(2024, 2)); err == nil {
+		t.Error("expected failed fetch not to be cached")
+	}
+}
+
+func TestFetch_Throttles(t *testing.T) {
+	var hits atomic.Int32
+	server := newInputServer(t, &hits)
+	fetcher := newTestFetcher(t, server.URL, "secret")
+	fetcher.MinInterval = 50 * time.Millisecond
+
+	start := time.Now()
+	for day := 1; day <= 3; day++ {
+		if _, err := fetcher.Fetch(context.Background(), 2024, day); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 2*fetcher.MinInterval {
+		t.Errorf("expected 3 requests to take at least %v, took %v", 2*fetcher.MinInterval, elapsed)
+	}
+}
+
+func TestNewFetcher_EmptySession(t *testing.T) {
+	sessionFile := filepath.Join(t.TempDir(), "session")
+	if err := os.WriteFile(sessionFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := puzzleio.NewFetcher(puzzleio.DefaultBaseURL, sessionFile, t.TempDir()); err == nil {
+		t.Error("expected error for empty session token")
+	}
+}
@@ -0,0 +1,73 @@
+package puzzleio
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// Fingerprint identifies a puzzle input by the SHA-256 hash of its normalised
+// content (see Normalize), so the same input stored with CRLF endings or
+// gzip-compressed has the same fingerprint.
+type Fingerprint [sha256.Size]byte
+
+// FingerprintOf reads r to EOF and returns the fingerprint of its normalised
+// content.
+func FingerprintOf(r io.Reader) (Fingerprint, error) {
+	hash := sha256.New()
+	if _, err := io.Copy(hash, Normalize(r)); err != nil {
+		return Fingerprint{}, fmt.Errorf("unable to fingerprint input: %w", err)
+	}
+
+	var fingerprint Fingerprint
+	hash.Sum(fingerprint[:0])
+
+	return fingerprint, nil
+}
+
+// ParseFingerprint parses the hexadecimal form returned by String.
+func ParseFingerprint(s string) (Fingerprint, error) {
+	var fingerprint Fingerprint
+	if err := fingerprint.UnmarshalText([]byte(s)); err != nil {
+		return Fingerprint{}, err
+	}
+
+	return fingerprint, nil
+}
+
+// String returns the fingerprint in hexadecimal form.
+func (f Fingerprint) String() string {
+	return hex.EncodeToString(f[:])
+}
+
+// Short returns the first 12 hexadecimal characters of the fingerprint, which
+// is enough to tell inputs apart in human readable output.
+func (f Fingerprint) Short() string {
+	return f.String()[:12]
+}
+
+// IsZero returns true if f is the zero value, e.g. a fingerprint of an input
+// that was never read.
+func (f Fingerprint) IsZero() bool {
+	return f == Fingerprint{}
+}
+
+// MarshalText implements encoding.TextMarshaler, so fingerprints can be used
+// as JSON values and map keys.
+func (f Fingerprint) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (f *Fingerprint) UnmarshalText(text []byte) error {
+	if hex.DecodedLen(len(text)) != len(f) {
+		return fmt.Errorf("invalid fingerprint %q: expected %d hexadecimal characters", text, 2*len(f))
+	}
+
+	if _, err := hex.Decode(f[:], text); err != nil {
+		return fmt.Errorf("invalid fingerprint %q: %w", text, err)
+	}
+
+	return nil
+}
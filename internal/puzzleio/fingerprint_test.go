@@ -0,0 +1,94 @@
+package puzzleio_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+func TestFingerprintOf_NormalisedContent(t *testing.T) {
+	const content = "3   4\n4   3\n"
+
+	want, err := puzzleio.FingerprintOf(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	variants := map[string]string{
+		"CRLF line endings": "3   4\r\n4   3\r\n",
+		"byte order mark":   "\ufeff" + content,
+		"gzip compressed":   gzipped(t, content),
+	}
+
+	for name, variant := range variants {
+		t.Run(name, func(t *testing.T) {
+			got, err := puzzleio.FingerprintOf(strings.NewReader(variant))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != want {
+				t.Errorf("got %s, want %s", got, want)
+			}
+		})
+	}
+
+	other, err := puzzleio.FingerprintOf(strings.NewReader("3   4\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == want {
+		t.Error("expected different inputs to have different fingerprints")
+	}
+}
+
+func TestPuzzleInput_Fingerprint(t *testing.T) {
+	const content = "7 6 4 2 1\n1 2 7 8 9\n"
+
+	want, err := puzzleio.FingerprintOf(strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: reading a single line still buffers more of the input, the
+	// fingerprint must cover the whole input regardless
+	puzzleInput := puzzleio.NewPuzzleInputFromReader("reports", strings.NewReader(content))
+	if _, err := puzzleInput.Reader.ReadString('\n'); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := puzzleInput.Fingerprint()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestFingerprint_JSONRoundTrip(t *testing.T) {
+	fingerprint, err := puzzleio.FingerprintOf(strings.NewReader("XMAS\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(map[puzzleio.Fingerprint]int{fingerprint: 18})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got map[puzzleio.Fingerprint]int
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got[fingerprint] != 18 {
+		t.Errorf("got %v, want fingerprint %s mapped to 18", got, fingerprint)
+	}
+
+	if _, err := puzzleio.ParseFingerprint("not-hex"); err == nil {
+		t.Error("expected error parsing invalid fingerprint")
+	}
+}
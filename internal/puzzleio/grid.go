@@ -0,0 +1,136 @@
+package puzzleio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// Point is the position of a cell in a Grid; Row 0 is the top row and Col 0
+// the left-most column.
+type Point struct {
+	Row int
+	Col int
+}
+
+// Add returns the point p offset by q.
+func (p Point) Add(q Point) Point {
+	return Point{Row: p.Row + q.Row, Col: p.Col + q.Col}
+}
+
+// Grid is a rectangular grid of runes, e.g. a word search or a map. Cells are
+// stored row by row.
+type Grid struct {
+	Width  int
+	Height int
+	cells  []rune
+}
+
+// ReadGrid reads a rectangular grid from r, one row per line. Rows must all
+// have the same (non-zero) width; a ragged row or a row containing a carriage
+// return results in a *ParseError.
+func ReadGrid(r io.Reader) (*Grid, error) {
+	var rows []Line[string]
+	for line, err := range Lines(r) {
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, line)
+	}
+
+	return newGrid(rows)
+}
+
+// NewGrid creates a grid from rows, see ReadGrid.
+func NewGrid(rows []string) (*Grid, error) {
+	lines := make([]Line[string], 0, len(rows))
+	for idx, row := range rows {
+		lines = append(lines, Line[string]{Num: idx + 1, Value: row})
+	}
+
+	return newGrid(lines)
+}
+
+// newGrid creates a grid from numbered rows, validating every row against the
+// width of the first.
+func newGrid(rows []Line[string]) (*Grid, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("grid is empty")
+	}
+
+	grid := &Grid{Width: utf8.RuneCountInString(rows[0].Value), Height: len(rows)}
+	if grid.Width == 0 {
+		return nil, &ParseError{Line: rows[0].Num, Column: 1, Expected: "non-empty grid row"}
+	}
+
+	grid.cells = make([]rune, 0, grid.Width*grid.Height)
+	for _, row := range rows {
+		if idx := strings.IndexByte(row.Value, '\r'); idx >= 0 {
+			return nil, &ParseError{Line: row.Num, Column: idx + 1, Token: "\r", Expected: "grid cell, not carriage return"}
+		}
+
+		if width := utf8.RuneCountInString(row.Value); width != grid.Width {
+			return nil, &ParseError{
+				Line:     row.Num,
+				Column:   1,
+				Token:    row.Value,
+				Expected: fmt.Sprintf("grid row of width %d", grid.Width),
+			}
+		}
+
+		grid.cells = append(grid.cells, []rune(row.Value)...)
+	}
+
+	return grid, nil
+}
+
+// InBounds returns true if p lies within the grid, false otherwise.
+func (g *Grid) InBounds(p Point) bool {
+	return p.Row >= 0 && p.Row < g.Height && p.Col >= 0 && p.Col < g.Width
+}
+
+// At returns the rune at p. Panics when p is out of bounds.
+func (g *Grid) At(p Point) rune {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: point %v out of bounds (%dx%d)", p, g.Width, g.Height))
+	}
+
+	return g.cells[p.Row*g.Width+p.Col]
+}
+
+// Set replaces the rune at p. Panics when p is out of bounds.
+func (g *Grid) Set(p Point, char rune) {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: point %v out of bounds (%dx%d)", p, g.Width, g.Height))
+	}
+
+	g.cells[p.Row*g.Width+p.Col] = char
+}
+
+// Points yields every point of the grid, row by row.
+func (g *Grid) Points() iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for row := range g.Height {
+			for col := range g.Width {
+				if !yield(Point{Row: row, Col: col}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// String returns the grid as newline separated rows.
+func (g *Grid) String() string {
+	var builder strings.Builder
+	for row := range g.Height {
+		builder.WriteString(string(g.cells[row*g.Width : (row+1)*g.Width]))
+		builder.WriteByte('\n')
+	}
+
+	return builder.String()
+}
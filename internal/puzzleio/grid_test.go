@@ -0,0 +1,102 @@
+package puzzleio_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+func TestReadGrid(t *testing.T) {
+	grid, err := puzzleio.ReadGrid(strings.NewReader("MMMS\nMSAM\nAMXS"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if grid.Width != 4 || grid.Height != 3 {
+		t.Errorf("got %dx%d grid, want 4x3", grid.Width, grid.Height)
+	}
+
+	tests := []struct {
+		point puzzleio.Point
+		want  rune
+	}{
+		{puzzleio.Point{Row: 0, Col: 0}, 'M'},
+		{puzzleio.Point{Row: 0, Col: 3}, 'S'},
+		{puzzleio.Point{Row: 2, Col: 2}, 'X'},
+	}
+
+	for _, tt := range tests {
+		if got := grid.At(tt.point); got != tt.want {
+			t.Errorf("At(%v): got %q, want %q", tt.point, got, tt.want)
+		}
+	}
+
+	if want := "MMMS\nMSAM\nAMXS\n"; grid.String() != want {
+		t.Errorf("got %q, want %q", grid.String(), want)
+	}
+}
+
+func TestReadGrid_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantLine   int
+		wantColumn int
+	}{
+		{"Ragged row", "XMAS\nXMA\nXMAS\n", 2, 1},
+		{"CRLF polluted row", "XMAS\r\nXMAS\r\n", 1, 5},
+		{"Empty first row", "\nXMAS\n", 1, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := puzzleio.ReadGrid(strings.NewReader(tt.input))
+
+			var parseErr *puzzleio.ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected *ParseError, got %v", err)
+			}
+
+			if parseErr.Line != tt.wantLine || parseErr.Column != tt.wantColumn {
+				t.Errorf("got position %d:%d, want %d:%d", parseErr.Line, parseErr.Column, tt.wantLine, tt.wantColumn)
+			}
+		})
+	}
+
+	if _, err := puzzleio.ReadGrid(strings.NewReader("")); err == nil {
+		t.Error("expected error reading empty grid")
+	}
+}
+
+func TestGrid_InBounds(t *testing.T) {
+	grid, err := puzzleio.NewGrid([]string{"ab", "cd"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		point puzzleio.Point
+		want  bool
+	}{
+		{puzzleio.Point{Row: 1, Col: 1}, true},
+		{puzzleio.Point{Row: -1, Col: 0}, false},
+		{puzzleio.Point{Row: 0, Col: 2}, false},
+		{puzzleio.Point{Row: 2, Col: 0}, false},
+	}
+
+	for _, tt := range tests {
+		if got := grid.InBounds(tt.point); got != tt.want {
+			t.Errorf("InBounds(%v): got %v, want %v", tt.point, got, tt.want)
+		}
+	}
+
+	var count int
+	for range grid.Points() {
+		count++
+	}
+	if count != 4 {
+		t.Errorf("expected 4 points, got %d", count)
+	}
+}
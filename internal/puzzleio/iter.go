@@ -0,0 +1,167 @@
+package puzzleio
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"unicode"
+)
+
+// Line pairs a value parsed from puzzle input with the (1-based) number of the
+// line it was read from.
+type Line[T any] struct {
+	Num   int
+	Value T
+}
+
+// Lines yields every line of r without its trailing newline. The last line is
+// yielded even when the input does not end with a newline.
+//
+// Iteration stops after the first read error, which is yielded together with
+// the number of the line that could not be read.
+func Lines(r io.Reader) iter.Seq2[Line[string], error] {
+	return func(yield func(Line[string], error) bool) {
+		reader, ok := r.(*bufio.Reader)
+		if !ok {
+			reader = bufio.NewReader(r)
+		}
+
+		for num := 1; ; num++ {
+			text, err := reader.ReadString('\n')
+			if err != nil && !errors.Is(err, io.EOF) {
+				yield(Line[string]{Num: num}, fmt.Errorf("line %d: %w", num, err))
+				return
+			}
+
+			// NOTE: reaching EOF without reading any data means the previous
+			// line was the last one; do not yield an empty trailing line.
+			if errors.Is(err, io.EOF) && text == "" {
+				return
+			}
+
+			if !yield(Line[string]{Num: num, Value: strings.TrimSuffix(text, "\n")}, nil) {
+				return
+			}
+
+			if errors.Is(err, io.EOF) {
+				return
+			}
+		}
+	}
+}
+
+// Fields yields the whitespace separated fields of every line of r, as split
+// by strings.Fields.
+func Fields(r io.Reader) iter.Seq2[Line[[]string], error] {
+	return func(yield func(Line[[]string], error) bool) {
+		for line, err := range Lines(r) {
+			if err != nil {
+				yield(Line[[]string]{Num: line.Num}, err)
+				return
+			}
+
+			if !yield(Line[[]string]{Num: line.Num, Value: strings.Fields(line.Value)}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Ints yields every whitespace separated base-ten integer of r, one at a time.
+//
+// Iteration stops at the first field that is not a valid integer; the
+// conversion error is yielded instead of a value.
+func Ints(r io.Reader) iter.Seq2[Line[int], error] {
+	return func(yield func(Line[int], error) bool) {
+		for row, err := range IntRows(r) {
+			if err != nil {
+				yield(Line[int]{Num: row.Num}, err)
+				return
+			}
+
+			for _, val := range row.Value {
+				if !yield(Line[int]{Num: row.Num, Value: val}, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// IntRows yields the whitespace separated base-ten integers of every line of
+// r as a single slice.
+//
+// Iteration stops at the first line containing a field that is not a valid
+// integer; a *ParseError pointing at that field is yielded instead of a value.
+func IntRows(r io.Reader) iter.Seq2[Line[[]int], error] {
+	return func(yield func(Line[[]int], error) bool) {
+		for line, err := range Lines(r) {
+			if err != nil {
+				yield(Line[[]int]{Num: line.Num}, err)
+				return
+			}
+
+			fields, columns := splitFields(line.Value)
+			row := make([]int, 0, len(fields))
+			for idx, field := range fields {
+				val, err := ParseInt(field, line.Num, columns[idx])
+				if err != nil {
+					yield(Line[[]int]{Num: line.Num}, err)
+					return
+				}
+
+				row = append(row, val)
+			}
+
+			if !yield(Line[[]int]{Num: line.Num, Value: row}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// splitFields splits s around runs of whitespace, like strings.Fields, and
+// additionally returns the 1-based column at which every field starts.
+func splitFields(s string) ([]string, []int) {
+	fields, offsets := splitOffsets(s, unicode.IsSpace)
+	for idx := range offsets {
+		offsets[idx]++
+	}
+
+	return fields, offsets
+}
+
+// splitOffsets splits s around runs of runes satisfying isSep and returns the
+// parts together with their 0-based byte offset in s.
+func splitOffsets(s string, isSep func(rune) bool) ([]string, []int) {
+	var (
+		parts   []string
+		offsets []int
+		start   = -1
+	)
+
+	for idx, char := range s {
+		if isSep(char) {
+			if start >= 0 {
+				parts = append(parts, s[start:idx])
+				offsets = append(offsets, start)
+				start = -1
+			}
+			continue
+		}
+
+		if start < 0 {
+			start = idx
+		}
+	}
+
+	if start >= 0 {
+		parts = append(parts, s[start:])
+		offsets = append(offsets, start)
+	}
+
+	return parts, offsets
+}
@@ -0,0 +1,120 @@
+package puzzleio_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []puzzleio.Line[string]
+	}{
+		{
+			name:  "Trailing newline",
+			input: "a\nb\n",
+			want:  []puzzleio.Line[string]{{Num: 1, Value: "a"}, {Num: 2, Value: "b"}},
+		},
+		{
+			name:  "Missing trailing newline keeps last line",
+			input: "a\nb",
+			want:  []puzzleio.Line[string]{{Num: 1, Value: "a"}, {Num: 2, Value: "b"}},
+		},
+		{
+			name:  "Blank lines are yielded",
+			input: "a\n\nb\n",
+			want:  []puzzleio.Line[string]{{Num: 1, Value: "a"}, {Num: 2, Value: ""}, {Num: 3, Value: "b"}},
+		},
+		{
+			name:  "Empty input",
+			input: "",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []puzzleio.Line[string]
+			for line, err := range puzzleio.Lines(strings.NewReader(tt.input)) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, line)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntRows(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []puzzleio.Line[[]int]
+		wantErr bool
+	}{
+		{
+			name:  "Site example reports",
+			input: "7 6 4 2 1\n1 2 7 8 9",
+			want: []puzzleio.Line[[]int]{
+				{Num: 1, Value: []int{7, 6, 4, 2, 1}},
+				{Num: 2, Value: []int{1, 2, 7, 8, 9}},
+			},
+		},
+		{
+			name:    "Invalid integer stops iteration",
+			input:   "1 2\n3 x\n5 6\n",
+			want:    []puzzleio.Line[[]int]{{Num: 1, Value: []int{1, 2}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []puzzleio.Line[[]int]
+			var gotErr error
+			for row, err := range puzzleio.IntRows(strings.NewReader(tt.input)) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				got = append(got, row)
+			}
+
+			if (gotErr != nil) != tt.wantErr {
+				t.Errorf("got error %v, want error: %v", gotErr, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInts(t *testing.T) {
+	var got []int
+	var lineNums []int
+	for val, err := range puzzleio.Ints(strings.NewReader("3   4\n4   3\n")) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got = append(got, val.Value)
+		lineNums = append(lineNums, val.Num)
+	}
+
+	if want := []int{3, 4, 4, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if want := []int{1, 1, 2, 2}; !reflect.DeepEqual(lineNums, want) {
+		t.Errorf("got line numbers %v, want %v", lineNums, want)
+	}
+}
@@ -0,0 +1,105 @@
+package puzzleio
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	utf8BOM   = []byte{0xef, 0xbb, 0xbf}
+)
+
+// Normalize returns a reader presenting the logical content of r, so every
+// solution sees identical lines regardless of how the input was stored:
+//   - gzip compressed input, detected by its magic bytes, is decompressed.
+//   - a leading UTF-8 byte order mark is stripped.
+//   - CRLF line endings are converted to LF.
+//
+// Detection happens on the first Read, errors (e.g. a corrupt gzip header) are
+// returned from Read as well.
+func Normalize(r io.Reader) io.Reader {
+	return &normalizer{src: r}
+}
+
+// normalizer lazily wraps its source on the first Read, see Normalize.
+type normalizer struct {
+	src io.Reader
+	r   io.Reader
+	err error
+}
+
+func (n *normalizer) Read(p []byte) (int, error) {
+	if n.r == nil && n.err == nil {
+		n.r, n.err = n.init()
+	}
+
+	if n.err != nil {
+		return 0, n.err
+	}
+
+	return n.r.Read(p)
+}
+
+// init detects compression and a byte order mark in the source.
+func (n *normalizer) init() (io.Reader, error) {
+	var r io.Reader = n.src
+
+	buffered := bufio.NewReader(r)
+	if magic, _ := buffered.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress input: %w", err)
+		}
+
+		buffered = bufio.NewReader(gzipReader)
+	}
+
+	if bom, _ := buffered.Peek(len(utf8BOM)); bytes.Equal(bom, utf8BOM) {
+		if _, err := buffered.Discard(len(utf8BOM)); err != nil {
+			return nil, err
+		}
+	}
+
+	return &crlfReader{r: buffered}, nil
+}
+
+// crlfReader converts CRLF line endings to LF. A lone carriage return is kept.
+type crlfReader struct {
+	r *bufio.Reader
+}
+
+func (c *crlfReader) Read(p []byte) (int, error) {
+	for {
+		n, err := c.r.Read(p)
+
+		kept := 0
+		for idx := 0; idx < n; idx++ {
+			if p[idx] == '\r' && c.followedByNewline(p[:n], idx) {
+				continue
+			}
+
+			p[kept] = p[idx]
+			kept++
+		}
+
+		// NOTE: avoid returning 0, nil when the chunk was a single dropped '\r'
+		if kept > 0 || n == 0 || err != nil {
+			return kept, err
+		}
+	}
+}
+
+// followedByNewline reports whether the byte after chunk[idx] is a '\n',
+// peeking into the underlying reader at the end of chunk.
+func (c *crlfReader) followedByNewline(chunk []byte, idx int) bool {
+	if idx+1 < len(chunk) {
+		return chunk[idx+1] == '\n'
+	}
+
+	next, err := c.r.Peek(1)
+	return err == nil && next[0] == '\n'
+}
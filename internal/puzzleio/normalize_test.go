@@ -0,0 +1,65 @@
+package puzzleio_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+func gzipped(t *testing.T, data string) string {
+	t.Helper()
+
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write([]byte(data)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return compressed.String()
+}
+
+func TestNormalize(t *testing.T) {
+	const want = "3   4\n4   3\n"
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain input", want, want},
+		{"CRLF line endings", "3   4\r\n4   3\r\n", want},
+		{"UTF-8 byte order mark", "\ufeff" + want, want},
+		{"gzip compressed", gzipped(t, want), want},
+		{"gzip compressed with BOM and CRLF", gzipped(t, "\ufeff3   4\r\n4   3\r\n"), want},
+		{"lone carriage return is kept", "a\rb\n", "a\rb\n"},
+		{"empty input", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// NOTE: read one byte at a time to exercise CRLF pairs split across reads
+			got, err := io.ReadAll(puzzleio.Normalize(iotest.OneByteReader(bytes.NewReader([]byte(tt.input)))))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize_CorruptGzip(t *testing.T) {
+	_, err := io.ReadAll(puzzleio.Normalize(bytes.NewReader([]byte{0x1f, 0x8b, 0x00})))
+	if err == nil {
+		t.Error("expected error reading corrupt gzip input")
+	}
+}
@@ -2,26 +2,88 @@ package puzzleio
 
 import (
 	"bufio"
+	"crypto/sha256"
 	"fmt"
+	"hash"
+	"io"
+	"io/fs"
 	"os"
 )
 
-// PuzzleInput wraps bufio.Reader and os.File.
+// PuzzleInput wraps a bufio.Reader around the source of a puzzle input and
+// keeps track of its path (or name) for diagnostics.
 type PuzzleInput struct {
 	Reader *bufio.Reader
-	File   *os.File
+	Path   string
+	closer io.Closer
+	hash   hash.Hash
 }
 
 // NewPuzzleInput opens the file at the specified path and returns a
-// PuzzleInput struct.
+// PuzzleInput struct. The caller is responsible for calling Close.
 func NewPuzzleInput(path string) (*PuzzleInput, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read input file: %w", err)
 	}
 
+	return NewPuzzleInputFromReader(path, file), nil
+}
+
+// NewPuzzleInputFromFS opens the file name in fsys, e.g. an embed.FS, and
+// returns a PuzzleInput struct. The caller is responsible for calling Close.
+func NewPuzzleInputFromFS(fsys fs.FS, name string) (*PuzzleInput, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read input file: %w", err)
+	}
+
+	return NewPuzzleInputFromReader(name, file), nil
+}
+
+// NewPuzzleInputFromReader returns a PuzzleInput reading from r, such as
+// os.Stdin, a strings.Reader or a gzip.Reader. The content of r is normalised,
+// see Normalize. The name is only used in diagnostics. When r implements
+// io.Closer, Close closes it.
+func NewPuzzleInputFromReader(name string, r io.Reader) *PuzzleInput {
+	closer, _ := r.(io.Closer)
+	hasher := sha256.New()
+
 	return &PuzzleInput{
-		Reader: bufio.NewReader(file),
-		File:   file,
-	}, nil
+		Reader: bufio.NewReader(io.TeeReader(Normalize(r), hasher)),
+		Path:   name,
+		closer: closer,
+		hash:   hasher,
+	}
+}
+
+// Fingerprint returns the fingerprint of the whole input. It first reads
+// whatever is left of Reader, e.g. the content a solution did not need, so the
+// input cannot be read afterwards.
+func (p *PuzzleInput) Fingerprint() (Fingerprint, error) {
+	var fingerprint Fingerprint
+	if _, err := io.Copy(io.Discard, p.Reader); err != nil {
+		return fingerprint, fmt.Errorf("unable to read input file: %w", err)
+	}
+	p.hash.Sum(fingerprint[:0])
+
+	return fingerprint, nil
+}
+
+// Read reads from the buffered puzzle input, making PuzzleInput an io.Reader.
+func (p *PuzzleInput) Read(b []byte) (int, error) {
+	return p.Reader.Read(b)
+}
+
+// Close closes the underlying source, if it can be closed. Calling Close more
+// than once is a no-op.
+func (p *PuzzleInput) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+
+	closer := p.closer
+	p.closer = nil
+
+	return closer.Close()
 }
@@ -0,0 +1,102 @@
+package puzzleio_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+// closeCounter counts the number of times Close has been called.
+type closeCounter struct {
+	io.Reader
+	closed int
+}
+
+func (c *closeCounter) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestNewPuzzleInputFromReader(t *testing.T) {
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write([]byte("3   4\n4   3\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	gzipReader, err := gzip.NewReader(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		reader io.Reader
+		want   string
+	}{
+		{"strings reader", strings.NewReader("3   4\n4   3\n"), "3   4\n4   3\n"},
+		{"gzip stream", gzipReader, "3   4\n4   3\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			puzzleInput := puzzleio.NewPuzzleInputFromReader(tt.name, tt.reader)
+			defer puzzleInput.Close()
+
+			got, err := io.ReadAll(puzzleInput)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPuzzleInputFromFS(t *testing.T) {
+	fsys := fstest.MapFS{"assets/puzzle.txt": {Data: []byte("XMAS\n")}}
+
+	puzzleInput, err := puzzleio.NewPuzzleInputFromFS(fsys, "assets/puzzle.txt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer puzzleInput.Close()
+
+	if puzzleInput.Path != "assets/puzzle.txt" {
+		t.Errorf("got path %q, want %q", puzzleInput.Path, "assets/puzzle.txt")
+	}
+
+	if _, err := puzzleio.NewPuzzleInputFromFS(fsys, "assets/missing.txt"); err == nil {
+		t.Error("expected error opening missing file")
+	}
+}
+
+func TestClose(t *testing.T) {
+	source := &closeCounter{Reader: strings.NewReader("")}
+	puzzleInput := puzzleio.NewPuzzleInputFromReader("counter", source)
+
+	for range 2 {
+		if err := puzzleInput.Close(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if source.closed != 1 {
+		t.Errorf("expected underlying reader to be closed once, got %d", source.closed)
+	}
+
+	// readers which cannot be closed are a no-op
+	if err := puzzleio.NewPuzzleInputFromReader("plain", strings.NewReader("")).Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package puzzleio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// InputDirEnv names the environment variable pointing at a directory
+	// containing puzzle inputs, using the same file names as DefaultInputDir.
+	InputDirEnv = "AOC_INPUT_DIR"
+	// DefaultInputDir is the directory, relative to the repository root, in
+	// which puzzle inputs are looked up by default.
+	DefaultInputDir = "assets"
+	// StdinPath is the -input flag value that reads the puzzle input from
+	// stdin.
+	StdinPath = "-"
+)
+
+// Source describes where a resolved puzzle input was read from.
+type Source int
+
+const (
+	SourceFlag    Source = iota // path given by the -input flag
+	SourceStdin                 // stdin, requested with '-input -'
+	SourceEnv                   // file in the AOC_INPUT_DIR directory
+	SourceDefault               // file in the DefaultInputDir of the repo
+)
+
+func (s Source) String() string {
+	switch s {
+	case SourceFlag:
+		return "flag"
+	case SourceStdin:
+		return "stdin"
+	case SourceEnv:
+		return "env " + InputDirEnv
+	case SourceDefault:
+		return "default"
+	}
+
+	return fmt.Sprintf("Source(%d)", int(s))
+}
+
+// Resolve opens the puzzle input, picking the first available source in the
+// following order:
+//  1. flagValue, the path given by the -input flag.
+//  2. stdin, when flagValue equals StdinPath.
+//  3. the file defaultName in the directory named by AOC_INPUT_DIR.
+//  4. the file defaultName in the DefaultInputDir of the current working
+//     directory or any of its parents.
+//
+// Returns the opened input and the source it was read from. The caller is
+// responsible for calling Close.
+func Resolve(flagValue string, defaultName string) (*PuzzleInput, Source, error) {
+	if flagValue == StdinPath {
+		// NOTE: hide os.Stdin's Close method; stdin is not ours to close
+		return NewPuzzleInputFromReader("<stdin>", struct{ io.Reader }{os.Stdin}), SourceStdin, nil
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, SourceDefault, fmt.Errorf("unable to resolve input: %w", err)
+	}
+
+	path, source, err := resolvePath(flagValue, defaultName, os.Getenv(InputDirEnv), wd)
+	if err != nil {
+		return nil, source, err
+	}
+
+	puzzleInput, err := NewPuzzleInput(path)
+	return puzzleInput, source, err
+}
+
+// resolvePath returns the path of the puzzle input and its source, see
+// Resolve. Stdin is handled by the caller.
+func resolvePath(flagValue string, defaultName string, inputDir string, wd string) (string, Source, error) {
+	if flagValue != "" {
+		return flagValue, SourceFlag, nil
+	}
+
+	if inputDir != "" {
+		return filepath.Join(inputDir, defaultName), SourceEnv, nil
+	}
+
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		path := filepath.Join(dir, DefaultInputDir, defaultName)
+		if _, err := os.Stat(path); err == nil {
+			return path, SourceDefault, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", SourceDefault, fmt.Errorf("unable to resolve input: %w", err)
+		}
+
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	return "", SourceDefault, fmt.Errorf(
+		"unable to resolve input: %s not found in %s or its parents; use -input or %s",
+		filepath.Join(DefaultInputDir, defaultName), wd, InputDirEnv,
+	)
+}
@@ -0,0 +1,53 @@
+package puzzleio
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePath(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "cmd", "historian-hysteria", "solution")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, DefaultInputDir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	defaultPath := filepath.Join(root, DefaultInputDir, "location_ids.txt")
+	if err := os.WriteFile(defaultPath, []byte("3   4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		flagValue  string
+		inputDir   string
+		wd         string
+		wantPath   string
+		wantSource Source
+	}{
+		{"flag wins over env", "other.txt", "/inputs", root, "other.txt", SourceFlag},
+		{"env wins over default", "", "/inputs", root, filepath.Join("/inputs", "location_ids.txt"), SourceEnv},
+		{"default in working directory", "", "", root, defaultPath, SourceDefault},
+		{"default in parent directory", "", "", nested, defaultPath, SourceDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, source, err := resolvePath(tt.flagValue, "location_ids.txt", tt.inputDir, tt.wd)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if path != tt.wantPath || source != tt.wantSource {
+				t.Errorf("got %s (%v), want %s (%v)", path, source, tt.wantPath, tt.wantSource)
+			}
+		})
+	}
+
+	if _, _, err := resolvePath("", "missing.txt", "", nested); err == nil {
+		t.Error("expected error resolving missing default input")
+	}
+}
@@ -0,0 +1,104 @@
+package puzzleio
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"strings"
+)
+
+// Section is a block of consecutive non-blank lines of a puzzle input, e.g.
+// the page ordering rules or the updates of a multi-part input.
+type Section struct {
+	Offset int      // number of input lines preceding the section
+	Lines  []string // lines of the section, without trailing newlines
+}
+
+// Sections splits r into blocks separated by one or more blank lines. Leading
+// and trailing blank lines are ignored.
+func Sections(r io.Reader) ([]Section, error) {
+	var (
+		sections []Section
+		current  *Section
+	)
+
+	for line, err := range Lines(r) {
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.TrimSpace(line.Value) == "" {
+			current = nil
+			continue
+		}
+
+		if current == nil {
+			sections = append(sections, Section{Offset: line.Num - 1})
+			current = &sections[len(sections)-1]
+		}
+
+		current.Lines = append(current.Lines, line.Value)
+	}
+
+	return sections, nil
+}
+
+// Reader returns a reader over the lines of the section, each terminated by a
+// newline. Line numbers reported while reading it are relative to the section;
+// see Relocate.
+func (s Section) Reader() io.Reader {
+	if len(s.Lines) == 0 {
+		return strings.NewReader("")
+	}
+
+	return strings.NewReader(strings.Join(s.Lines, "\n") + "\n")
+}
+
+// Relocate moves the line of a *ParseError, produced while parsing the
+// section's Reader, to its line in the original input. A *ParseError is
+// returned as a relocated copy; one wrapped by err cannot be copied without
+// losing the wrapping and is relocated in place. Either way it is marked, so
+// relocating an error again leaves its line alone. Other errors are returned
+// as-is.
+func (s Section) Relocate(err error) error {
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.relocated {
+		return err
+	}
+
+	if err == error(parseErr) {
+		relocated := *parseErr
+		parseErr = &relocated
+		err = parseErr
+	}
+
+	parseErr.Line += s.Offset
+	parseErr.relocated = true
+
+	return err
+}
+
+// Fields yields the fields of every line of the section, see Fields. Line
+// numbers refer to the original input.
+func (s Section) Fields() iter.Seq2[Line[[]string], error] {
+	return relocateSeq(s, Fields(s.Reader()))
+}
+
+// IntRows yields the integers of every line of the section, see IntRows. Line
+// numbers and parse errors refer to the original input.
+func (s Section) IntRows() iter.Seq2[Line[[]int], error] {
+	return relocateSeq(s, IntRows(s.Reader()))
+}
+
+// relocateSeq shifts the line numbers and errors yielded by seq, which reads
+// the section, to lines of the original input.
+func relocateSeq[T any](s Section, seq iter.Seq2[Line[T], error]) iter.Seq2[Line[T], error] {
+	return func(yield func(Line[T], error) bool) {
+		for line, err := range seq {
+			line.Num += s.Offset
+			if !yield(line, s.Relocate(err)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package puzzleio_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+)
+
+func TestSections(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []puzzleio.Section
+	}{
+		{
+			name:  "Rules followed by updates",
+			input: "47|53\n97|13\n\n75,47,61\n",
+			want: []puzzleio.Section{
+				{Offset: 0, Lines: []string{"47|53", "97|13"}},
+				{Offset: 3, Lines: []string{"75,47,61"}},
+			},
+		},
+		{
+			name:  "Leading, repeated and trailing blank lines",
+			input: "\n##\n#.\n\n\n<>^v\n\n",
+			want: []puzzleio.Section{
+				{Offset: 1, Lines: []string{"##", "#."}},
+				{Offset: 5, Lines: []string{"<>^v"}},
+			},
+		},
+		{
+			name:  "Single section",
+			input: "1 2\n3 4",
+			want:  []puzzleio.Section{{Offset: 0, Lines: []string{"1 2", "3 4"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := puzzleio.Sections(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSection_Reader(t *testing.T) {
+	section := puzzleio.Section{Offset: 3, Lines: []string{"a", "b"}}
+
+	got, err := io.ReadAll(section.Reader())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "a\nb\n"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestSection_IntRowsKeepsLineNumbers(t *testing.T) {
+	sections, err := puzzleio.Sections(strings.NewReader("1 2\n\n3 4\n5 x\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var lineNums []int
+	var gotErr error
+	for row, err := range sections[1].IntRows() {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		lineNums = append(lineNums, row.Num)
+	}
+
+	if want := []int{3}; !reflect.DeepEqual(lineNums, want) {
+		t.Errorf("got line numbers %v, want %v", lineNums, want)
+	}
+
+	var parseErr *puzzleio.ParseError
+	if !errors.As(gotErr, &parseErr) {
+		t.Fatalf("expected *ParseError, got %v", gotErr)
+	}
+
+	if parseErr.Line != 4 || parseErr.Column != 3 {
+		t.Errorf("got position %d:%d, want 4:3", parseErr.Line, parseErr.Column)
+	}
+}
+
+func TestSection_RelocateTwice(t *testing.T) {
+	section := puzzleio.Section{Offset: 2, Lines: []string{"3 4", "5 x"}}
+	original := &puzzleio.ParseError{Line: 2, Column: 3, Token: "x", Expected: puzzleio.KindInt}
+
+	var tests = []struct {
+		name string
+		err  error
+	}{
+		{"parse error", original},
+		{"wrapped parse error", fmt.Errorf("updates: %w", &puzzleio.ParseError{Line: 2, Column: 3})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := section.Relocate(section.Relocate(tt.err))
+
+			var parseErr *puzzleio.ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("expected *ParseError, got %v", err)
+			}
+			if parseErr.Line != 4 {
+				t.Errorf("got line %d, want 4", parseErr.Line)
+			}
+		})
+	}
+
+	if original.Line != 2 {
+		t.Errorf("expected relocating to copy the error, got line %d", original.Line)
+	}
+
+	// NOTE: errors yielded by the section's iterators are relocated already
+	for _, err := range section.IntRows() {
+		if err == nil {
+			continue
+		}
+
+		var parseErr *puzzleio.ParseError
+		if !errors.As(section.Relocate(err), &parseErr) || parseErr.Line != 4 {
+			t.Errorf("got %v, want an error on line 4", err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package puzzlepage
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Width is the column paragraphs and list items are wrapped at by Markdown.
+const Width = 80
+
+var (
+	elementPattern = regexp.MustCompile(`(?s)<h2(?:\s[^>]*)?>(.*?)</h2>|<p(?:\s[^>]*)?>(.*?)</p>|<pre(?:\s[^>]*)?>(.*?)</pre>|<ul(?:\s[^>]*)?>(.*?)</ul>`)
+	itemPattern    = regexp.MustCompile(`(?s)<li(?:\s[^>]*)?>(.*?)</li>`)
+	strongPattern  = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>|<em><code>(.*?)</code></em>`)
+	codePattern    = regexp.MustCompile(`(?s)<code>(.*?)</code>`)
+	emPattern      = regexp.MustCompile(`(?s)<em(?:\s[^>]*)?>(.*?)</em>`)
+	linkPattern    = regexp.MustCompile(`(?s)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	headingPattern = regexp.MustCompile(`^-+\s*(.*?)\s*-+$`)
+)
+
+// Markdown renders the descriptions of the parts on page as Markdown: a
+// heading per part, paragraphs and lists wrapped at Width, code blocks fenced
+// and inline code, emphasis and links kept. Emphasised inline code, which the
+// descriptions use for answers, is rendered in bold. Anything outside the
+// descriptions, such as the answers given, is left out.
+func Markdown(page string) string {
+	var blocks []string
+	for _, article := range Articles(page) {
+		for _, match := range elementPattern.FindAllStringSubmatch(article, -1) {
+			switch {
+			case match[1] != "":
+				heading := Text(match[1])
+				if parts := headingPattern.FindStringSubmatch(heading); parts != nil {
+					heading = parts[1]
+				}
+				blocks = append(blocks, "## "+heading)
+			case match[2] != "":
+				blocks = append(blocks, wrap(inline(match[2]), "", ""))
+			case match[3] != "":
+				code := html.UnescapeString(tagPattern.ReplaceAllString(match[3], ""))
+				blocks = append(blocks, "```\n"+strings.TrimSuffix(code, "\n")+"\n```")
+			case match[4] != "":
+				var items []string
+				for _, item := range itemPattern.FindAllStringSubmatch(match[4], -1) {
+					items = append(items, wrap(inline(item[1]), "- ", "  "))
+				}
+				blocks = append(blocks, strings.Join(items, "\n"))
+			}
+		}
+	}
+
+	if len(blocks) == 0 {
+		return ""
+	}
+
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// inline renders an HTML fragment of inline elements as Markdown on a single
+// line.
+func inline(fragment string) string {
+	fragment = strongPattern.ReplaceAllStringFunc(fragment, func(match string) string {
+		parts := strongPattern.FindStringSubmatch(match)
+		return "**`" + code(parts[1]+parts[2]) + "`**"
+	})
+	fragment = codePattern.ReplaceAllStringFunc(fragment, func(match string) string {
+		return "`" + code(codePattern.FindStringSubmatch(match)[1]) + "`"
+	})
+	fragment = emPattern.ReplaceAllString(fragment, "*$1*")
+	fragment = linkPattern.ReplaceAllString(fragment, "[$2]($1)")
+
+	return strings.Join(strings.Fields(html.UnescapeString(tagPattern.ReplaceAllString(fragment, ""))), " ")
+}
+
+// code returns the text of inline code, escaping the HTML special characters
+// again so they survive unescaping the surrounding fragment.
+func code(fragment string) string {
+	return html.EscapeString(html.UnescapeString(tagPattern.ReplaceAllString(fragment, "")))
+}
+
+// wrap breaks line into lines of at most Width columns where possible,
+// prefixing the first with first and the others with rest.
+func wrap(line string, first string, rest string) string {
+	var b strings.Builder
+	column := -1
+	for _, word := range strings.Fields(line) {
+		switch {
+		case column < 0:
+			b.WriteString(first)
+			column = len(first)
+		case column+1+utf8.RuneCountInString(word) > Width:
+			b.WriteString("\n" + rest)
+			column = len(rest)
+		default:
+			b.WriteString(" ")
+			column++
+		}
+
+		b.WriteString(word)
+		column += utf8.RuneCountInString(word)
+	}
+
+	return b.String()
+}
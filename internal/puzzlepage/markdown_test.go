@@ -0,0 +1,69 @@
+package puzzlepage_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzlepage"
+)
+
+func TestMarkdown(t *testing.T) {
+	var tests = []struct {
+		name string
+		page string
+		want string
+	}{
+		{
+			name: "both parts",
+			page: reportsPage,
+			want: "## Day 2: Red-Nosed Reports\n\n" +
+				"For example:\n\n" +
+				"```\n7 6 4 2 1\n1 2 7 8 9\n9 7 6 2 1\n1 3 2 4 5\n8 6 4 4 1\n1 3 6 7 9\n```\n\n" +
+				"So, in this example, **`2`** reports are *safe*.\n\n" +
+				"## Part Two\n\n" +
+				"Thanks to the Problem Dampener, **`4`** reports are actually *safe*!\n",
+		},
+		{
+			name: "lists, links and escapes",
+			page: `<article><p>Read the <a href="/2024/about" target="_blank">about page</a>:</p>
+<ul>
+<li>Compare <code>a &lt; b</code> &amp; <em class="star">stars</em>.</li>
+<li>Then stop.</li>
+</ul></article>`,
+			want: "Read the [about page](/2024/about):\n\n" +
+				"- Compare `a < b` & *stars*.\n" +
+				"- Then stop.\n",
+		},
+		{
+			name: "no articles",
+			page: "<html><body>Please log in.</body></html>",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := puzzlepage.Markdown(tt.page); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdown_Wraps(t *testing.T) {
+	words := strings.Repeat("lorem ipsum ", 30)
+	page := "<article><p>" + words + "</p><ul><li>" + words + "</li></ul></article>"
+
+	lines := strings.Split(strings.TrimSuffix(puzzlepage.Markdown(page), "\n"), "\n")
+	for _, line := range lines {
+		if len(line) > puzzlepage.Width {
+			t.Errorf("got line of %d columns, want at most %d: %q", len(line), puzzlepage.Width, line)
+		}
+	}
+
+	for _, line := range lines[len(lines)-3:] {
+		if !strings.HasPrefix(line, "- ") && !strings.HasPrefix(line, "  ") {
+			t.Errorf("expected list item lines to be indented, got %q", line)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package puzzlepage extracts the descriptions of the parts of a puzzle from
+// its HTML page, as served by an AoC-compatible endpoint, and the examples
+// they contain.
+package puzzlepage
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	blockPattern   = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	answerPattern  = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>|<em><code>(.*?)</code></em>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Articles returns the HTML of the description of each part on page, in order.
+// The description of the second part is only on the page once the first part
+// is solved.
+func Articles(page string) []string {
+	var articles []string
+	for _, match := range articlePattern.FindAllStringSubmatch(page, -1) {
+		articles = append(articles, match[1])
+	}
+
+	return articles
+}
+
+// Example is the example input of a part and its answer.
+type Example struct {
+	Part   int
+	Input  string
+	Answer string // empty when the description does not give one
+}
+
+// Examples returns the example of each part described on page.
+//
+// Descriptions show the example input in a code block and emphasise its
+// answer in inline code, usually as the last one of the description. A
+// description can have several code blocks, illustrating steps of the puzzle
+// on a smaller input; the longest block is taken as the example. A part
+// without a code block of its own, typically the second, reuses the example of
+// the part before it.
+func Examples(page string) []Example {
+	var examples []Example
+	var input string
+	for idx, article := range Articles(page) {
+		if block := longestBlock(article); block != "" {
+			input = block
+		}
+
+		var answer string
+		if matches := answerPattern.FindAllStringSubmatch(article, -1); matches != nil {
+			last := matches[len(matches)-1]
+			answer = Text(last[1] + last[2])
+		}
+
+		if input == "" {
+			continue
+		}
+
+		examples = append(examples, Example{Part: idx + 1, Input: input, Answer: answer})
+	}
+
+	return examples
+}
+
+// longestBlock returns the text of the longest code block in article, ending
+// with a newline, or an empty string if it has none.
+func longestBlock(article string) string {
+	var longest string
+	for _, match := range blockPattern.FindAllStringSubmatch(article, -1) {
+		if block := html.UnescapeString(tagPattern.ReplaceAllString(match[1], "")); len(block) > len(longest) {
+			longest = block
+		}
+	}
+
+	if longest != "" && !strings.HasSuffix(longest, "\n") {
+		longest += "\n"
+	}
+
+	return longest
+}
+
+// Text returns the text of an HTML fragment of a page, e.g. an article, without
+// tags and surrounding white space.
+func Text(fragment string) string {
+	return strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(fragment, "")))
+}
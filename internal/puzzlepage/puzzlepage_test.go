@@ -0,0 +1,111 @@
+package puzzlepage_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/puzzlepage"
+)
+
+const reportsPage = `<!DOCTYPE html>
+<html lang="en-us">
+<body>
+<main>
+<article class="day-desc"><h2>--- Day 2: Red-Nosed Reports ---</h2>
+<p>For example:</p>
+<pre><code>7 6 4 2 1
+1 2 7 8 9
+9 7 6 2 1
+1 3 2 4 5
+8 6 4 4 1
+1 3 6 7 9
+</code></pre>
+<p>So, in this example, <code><em>2</em></code> reports are <em>safe</em>.</p>
+</article>
+<p>Your puzzle answer was <code>526</code>.</p>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<p>Thanks to the Problem Dampener, <code><em>4</em></code> reports are actually <em>safe</em>!</p>
+</article>
+</main>
+</body>
+</html>
+`
+
+const memoryPage = `<main>
+<article class="day-desc"><h2>--- Day 3: Mull It Over ---</h2>
+<p>For example, <code>mul(44,46)</code> multiplies <code>44</code> by <code>46</code>.</p>
+<pre><code>x<em>mul(2,4)</em>%&amp;mul[3,7]!@^do_not_<em>mul(5,5)</em>+mul(32,64]then(<em>mul(11,8)mul(8,5)</em>)</code></pre>
+<p>Adding up the result of each instruction produces <code><em>161</em></code>.</p>
+</article>
+<article class="day-desc"><h2 id="part2">--- Part Two ---</h2>
+<pre><code>x<em>mul(2,4)</em>&amp;mul[3,7]!^<em>don't()</em>_mul(5,5)+mul(32,64](mul(11,8)un<em>do()</em>?<em>mul(8,5)</em>)</code></pre>
+<p>This time, the sum of the results is <em><code>48</code></em>.</p>
+</article>
+</main>
+`
+
+func TestExamples(t *testing.T) {
+	var tests = []struct {
+		name string
+		page string
+		want []puzzlepage.Example
+	}{
+		{
+			name: "second part reuses example",
+			page: reportsPage,
+			want: []puzzlepage.Example{
+				{Part: 1, Input: "7 6 4 2 1\n1 2 7 8 9\n9 7 6 2 1\n1 3 2 4 5\n8 6 4 4 1\n1 3 6 7 9\n", Answer: "2"},
+				{Part: 2, Input: "7 6 4 2 1\n1 2 7 8 9\n9 7 6 2 1\n1 3 2 4 5\n8 6 4 4 1\n1 3 6 7 9\n", Answer: "4"},
+			},
+		},
+		{
+			name: "example per part with markup",
+			page: memoryPage,
+			want: []puzzlepage.Example{
+				{Part: 1, Input: "xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))\n", Answer: "161"},
+				{Part: 2, Input: "xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))\n", Answer: "48"},
+			},
+		},
+		{
+			name: "no articles",
+			page: "<html><body>Please log in.</body></html>",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := puzzlepage.Examples(tt.page)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArticles(t *testing.T) {
+	articles := puzzlepage.Articles(reportsPage)
+	if len(articles) != 2 {
+		t.Fatalf("got %d articles, want 2", len(articles))
+	}
+}
+
+func TestText(t *testing.T) {
+	var tests = []struct {
+		name     string
+		fragment string
+		want     string
+	}{
+		{"answer", "<code><em>11</em></code>", "11"},
+		{"entities", "<p>That&apos;s the <em>right</em> answer!</p>\n", "That's the right answer!"},
+		{"empty", "<span></span>", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := puzzlepage.Text(tt.fragment); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package registry
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// Year is the year of the calendar the days are registered for.
+const Year = 2024
+
+// Day describes the solution of a single puzzle of the calendar.
+type Day struct {
+	Number int    // day of the calendar, 1 to 25
+	Slug   string // name of the puzzle, e.g. "historian-hysteria"
+	Input  string // default input file name, see puzzleio.Resolve
+	// Package is the name of the package solving the day, in the directory
+	// internal/days/<Package> of the repository.
+	Package string
+	// New returns a new, unparsed solution of the day.
+	New func() solution.Solution
+	// Options, if set, returns the runtime options of the day, set to their
+	// defaults. New creates solutions using the defaults.
+	Options func() solution.Options
+}
+
+var (
+	mu   sync.RWMutex
+	days = make(map[int]Day)
+)
+
+// Register makes a day available to the runner. It is meant to be called from
+// the init function of the day's package and panics when the day is invalid or
+// registered twice.
+func Register(day Day) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if day.Number < 1 || day.Number > 25 {
+		panic(fmt.Sprintf("registry: invalid day number %d for %q", day.Number, day.Slug))
+	}
+
+	if day.New == nil {
+		panic(fmt.Sprintf("registry: day %d (%s) has no New function", day.Number, day.Slug))
+	}
+
+	if existing, ok := days[day.Number]; ok {
+		panic(fmt.Sprintf("registry: day %d registered twice (%s, %s)", day.Number, existing.Slug, day.Slug))
+	}
+
+	days[day.Number] = day
+}
+
+// Lookup returns the day with the given number, or false when it is not
+// registered.
+func Lookup(number int) (Day, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	day, ok := days[number]
+	return day, ok
+}
+
+// LookupSlug returns the day with the given slug, or false when it is not
+// registered.
+func LookupSlug(slug string) (Day, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	for _, day := range days {
+		if day.Slug == slug {
+			return day, true
+		}
+	}
+
+	return Day{}, false
+}
+
+// All returns every registered day, ordered by number.
+func All() []Day {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]Day, 0, len(days))
+	for _, day := range days {
+		all = append(all, day)
+	}
+
+	slices.SortFunc(all, func(a, b Day) int {
+		return a.Number - b.Number
+	})
+
+	return all
+}
@@ -0,0 +1,71 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// withEmptyRegistry runs the test against an empty registry, restoring the
+// registered days afterwards.
+func withEmptyRegistry(t *testing.T) {
+	t.Helper()
+
+	saved := days
+	days = make(map[int]Day)
+	t.Cleanup(func() { days = saved })
+}
+
+func newNothing() solution.Solution {
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	withEmptyRegistry(t)
+
+	Register(Day{Number: 4, Slug: "ceres-search", New: newNothing})
+	Register(Day{Number: 1, Slug: "historian-hysteria", New: newNothing})
+
+	all := All()
+	if len(all) != 2 || all[0].Number != 1 || all[1].Number != 4 {
+		t.Errorf("expected days 1 and 4 ordered by number, got %+v", all)
+	}
+
+	if day, ok := Lookup(4); !ok || day.Slug != "ceres-search" {
+		t.Errorf("expected day 4 to be ceres-search, got %+v", day)
+	}
+
+	if day, ok := LookupSlug("historian-hysteria"); !ok || day.Number != 1 {
+		t.Errorf("expected historian-hysteria to be day 1, got %+v", day)
+	}
+
+	if _, ok := Lookup(2); ok {
+		t.Error("expected day 2 not to be registered")
+	}
+}
+
+func TestRegister_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		day  Day
+	}{
+		{"day out of range", Day{Number: 26, Slug: "too-late", New: newNothing}},
+		{"missing new", Day{Number: 2, Slug: "red-nosed-reports"}},
+		{"duplicate day", Day{Number: 1, Slug: "duplicate", New: newNothing}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withEmptyRegistry(t)
+			Register(Day{Number: 1, Slug: "historian-hysteria", New: newNothing})
+
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected Register to panic")
+				}
+			}()
+
+			Register(tt.day)
+		})
+	}
+}
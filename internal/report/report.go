@@ -0,0 +1,279 @@
+// Package report renders tabular results, such as answers, timings and
+// verification results, in human and machine readable formats.
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Format is an output format of a Table.
+type Format string
+
+const (
+	Text      Format = "text"     // aligned columns
+	JSON      Format = "json"     // array of objects
+	JSONLines Format = "jsonl"    // one object per line
+	CSV       Format = "csv"      // header followed by rows
+	Markdown  Format = "markdown" // GitHub flavoured table
+)
+
+// Formats lists all supported formats.
+var Formats = []Format{Text, JSON, JSONLines, CSV, Markdown}
+
+// ParseFormat returns the Format named s.
+func ParseFormat(s string) (Format, error) {
+	for _, format := range Formats {
+		if string(format) == s {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown format %q, expected one of %v", s, Formats)
+}
+
+// String implements flag.Value.
+func (f *Format) String() string {
+	return string(*f)
+}
+
+// Set implements flag.Value.
+func (f *Format) Set(s string) error {
+	format, err := ParseFormat(s)
+	if err != nil {
+		return err
+	}
+
+	*f = format
+	return nil
+}
+
+// Shortener is implemented by values with a shorter human readable form, such
+// as puzzleio.Fingerprint. The short form is used by the Text and Markdown
+// formats only.
+type Shortener interface {
+	Short() string
+}
+
+// Table is a list of rows with named columns. Cells hold typed values, e.g.
+// ints, strings, answers or durations, which every format renders in its own
+// way: durations are written as nanoseconds by machine readable formats.
+type Table struct {
+	Columns []string
+	Rows    [][]any
+}
+
+// NewTable returns an empty table with the given columns.
+func NewTable(columns ...string) *Table {
+	return &Table{Columns: columns}
+}
+
+// Append adds a row; values are given in the order of the columns. A nil
+// value is an empty cell.
+func (t *Table) Append(values ...any) {
+	t.Rows = append(t.Rows, values)
+}
+
+// Write renders t in format to w.
+func Write(w io.Writer, format Format, t *Table) error {
+	switch format {
+	case Text, "":
+		return writeText(w, t)
+	case JSON:
+		return writeJSON(w, t)
+	case JSONLines:
+		return writeJSONLines(w, t)
+	case CSV:
+		return writeCSV(w, t)
+	case Markdown:
+		return writeMarkdown(w, t)
+	}
+
+	return fmt.Errorf("unknown format %q", format)
+}
+
+func writeText(w io.Writer, t *Table) error {
+	var aligned bytes.Buffer
+	tw := tabwriter.NewWriter(&aligned, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(t.Columns, "\t"))
+	for _, row := range t.Rows {
+		fmt.Fprintln(tw, strings.Join(cells(row, humanString), "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	// NOTE: trailing empty cells are padded by tabwriter; trim them
+	for _, line := range strings.SplitAfter(aligned.String(), "\n") {
+		if line == "" {
+			continue
+		}
+
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " \n")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeMarkdown(w io.Writer, t *Table) error {
+	separators := make([]string, len(t.Columns))
+	for idx := range separators {
+		separators[idx] = "---"
+	}
+
+	columns := make([]string, len(t.Columns))
+	for idx, column := range t.Columns {
+		columns[idx] = escapeMarkdown(column)
+	}
+
+	for _, header := range [][]string{columns, separators} {
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(header, " | ")); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range t.Rows {
+		escaped := cells(row, func(v any) string {
+			return escapeMarkdown(humanString(v))
+		})
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(escaped, " | ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// escapeMarkdown escapes the pipes in a table cell, which would end the cell.
+func escapeMarkdown(cell string) string {
+	return strings.ReplaceAll(cell, "|", `\|`)
+}
+
+func writeCSV(w io.Writer, t *Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Columns); err != nil {
+		return err
+	}
+
+	for _, row := range t.Rows {
+		if err := cw.Write(cells(row, machineString)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeJSON(w io.Writer, t *Table) error {
+	objects := make([]json.RawMessage, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		object, err := t.object(row)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, object)
+	}
+
+	data, err := json.MarshalIndent(objects, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+func writeJSONLines(w io.Writer, t *Table) error {
+	for _, row := range t.Rows {
+		object, err := t.object(row)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "%s\n", object); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// object encodes row as a JSON object, keeping the order of the columns.
+func (t *Table) object(row []any) (json.RawMessage, error) {
+	var builder strings.Builder
+	builder.WriteByte('{')
+	for idx, column := range t.Columns {
+		if idx > 0 {
+			builder.WriteByte(',')
+		}
+
+		var value any
+		if idx < len(row) {
+			value = row[idx]
+		}
+		if duration, ok := value.(time.Duration); ok {
+			value = duration.Nanoseconds()
+		}
+
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", column, err)
+		}
+
+		builder.Write(key)
+		builder.WriteByte(':')
+		builder.Write(encoded)
+	}
+	builder.WriteByte('}')
+
+	return json.RawMessage(builder.String()), nil
+}
+
+// cells converts the values of row to strings using str.
+func cells(row []any, str func(any) string) []string {
+	strs := make([]string, len(row))
+	for idx, value := range row {
+		strs[idx] = str(value)
+	}
+
+	return strs
+}
+
+// humanString returns the human readable form of v.
+func humanString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case Shortener:
+		return v.Short()
+	}
+
+	return fmt.Sprint(v)
+}
+
+// machineString returns the machine readable form of v.
+func machineString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case time.Duration:
+		return strconv.FormatInt(v.Nanoseconds(), 10)
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,156 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/report"
+)
+
+type fingerprint string
+
+func (f fingerprint) Short() string {
+	return string(f[:4])
+}
+
+func exampleTable() *report.Table {
+	table := report.NewTable("day", "slug", "input", "median")
+	table.Append(1, "historian-hysteria", fingerprint("43d62309"), 1500*time.Microsecond)
+	table.Append(nil, "a|b, \"c\"", nil, nil)
+
+	return table
+}
+
+func TestWrite(t *testing.T) {
+	tests := []struct {
+		format report.Format
+		want   string
+	}{
+		{
+			format: report.Text,
+			want: "day  slug                input  median\n" +
+				"1    historian-hysteria  43d6   1.5ms\n" +
+				"     a|b, \"c\"\n",
+		},
+		{
+			format: report.JSON,
+			want: "[\n" +
+				"  {\n" +
+				"    \"day\": 1,\n" +
+				"    \"slug\": \"historian-hysteria\",\n" +
+				"    \"input\": \"43d62309\",\n" +
+				"    \"median\": 1500000\n" +
+				"  },\n" +
+				"  {\n" +
+				"    \"day\": null,\n" +
+				"    \"slug\": \"a|b, \\\"c\\\"\",\n" +
+				"    \"input\": null,\n" +
+				"    \"median\": null\n" +
+				"  }\n" +
+				"]\n",
+		},
+		{
+			format: report.JSONLines,
+			want: `{"day":1,"slug":"historian-hysteria","input":"43d62309","median":1500000}` + "\n" +
+				`{"day":null,"slug":"a|b, \"c\"","input":null,"median":null}` + "\n",
+		},
+		{
+			format: report.CSV,
+			want: "day,slug,input,median\n" +
+				"1,historian-hysteria,43d62309,1500000\n" +
+				",\"a|b, \"\"c\"\"\",,\n",
+		},
+		{
+			format: report.Markdown,
+			want: "| day | slug | input | median |\n" +
+				"| --- | --- | --- | --- |\n" +
+				"| 1 | historian-hysteria | 43d6 | 1.5ms |\n" +
+				"|  | a\\|b, \"c\" |  |  |\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var out bytes.Buffer
+			if err := report.Write(&out, tt.format, exampleTable()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := out.String(); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite_SpecialColumns(t *testing.T) {
+	table := report.NewTable("a|b", "nul\x00", "emoji \U0001F384")
+	table.Append(1, 2, 3)
+
+	var out bytes.Buffer
+	if err := report.Write(&out, report.JSONLines, table); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var object map[string]int
+	if err := json.Unmarshal(out.Bytes(), &object); err != nil {
+		t.Fatalf("expected valid JSON, got %q: %v", out.String(), err)
+	}
+	if want := map[string]int{"a|b": 1, "nul\x00": 2, "emoji \U0001F384": 3}; !reflect.DeepEqual(object, want) {
+		t.Errorf("got %v, want %v", object, want)
+	}
+
+	out.Reset()
+	if err := report.Write(&out, report.Markdown, table); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "| a\\|b | nul\x00 | emoji \U0001F384 |\n"; !strings.HasPrefix(out.String(), want) {
+		t.Errorf("got %q, want header %q", out.String(), want)
+	}
+}
+
+// failingWriter fails every write, like a closed pipe.
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestWrite_WriterError(t *testing.T) {
+	// NOTE: a table without rows only writes its header, which jsonl lacks
+	table := report.NewTable("day", "answer")
+
+	for _, format := range report.Formats {
+		if format == report.JSONLines {
+			continue
+		}
+
+		t.Run(string(format), func(t *testing.T) {
+			if err := report.Write(failingWriter{}, format, table); err == nil {
+				t.Error("expected error writing to a failing writer")
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, want := range report.Formats {
+		got, err := report.ParseFormat(string(want))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+
+	if _, err := report.ParseFormat("yaml"); err == nil {
+		t.Error("expected error parsing unknown format")
+	}
+}
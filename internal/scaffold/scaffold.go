@@ -0,0 +1,219 @@
+// Package scaffold generates the files of a new day of the calendar from
+// templates matching the layout of the existing days.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// DaysFile is the file, relative to the module root, importing every day so
+// it registers itself with the runner.
+const DaysFile = "cmd/aoc/days.go"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+var slugPattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Day describes the day to generate.
+type Day struct {
+	Number int    // day of the calendar, 1 to 25
+	Slug   string // kebab-case name, e.g. historian-hysteria
+}
+
+// Package returns the name of the package of the day: its slug without
+// dashes.
+func (d Day) Package() string {
+	return strings.ReplaceAll(d.Slug, "-", "")
+}
+
+// Input returns the file name of the puzzle input in the assets directory.
+func (d Day) Input() string {
+	return d.Slug + ".txt"
+}
+
+// Validate returns an error if d cannot be generated.
+func (d Day) Validate() error {
+	if d.Number < 1 || d.Number > 25 {
+		return fmt.Errorf("invalid day %d, expected 1 to 25", d.Number)
+	}
+
+	if !slugPattern.MatchString(d.Slug) {
+		return fmt.Errorf("invalid slug %q, expected lowercase words separated by dashes", d.Slug)
+	}
+
+	if token.IsKeyword(d.Package()) {
+		return fmt.Errorf("invalid slug %q: %s is a Go keyword", d.Slug, d.Package())
+	}
+
+	return nil
+}
+
+// templateData is passed to the templates of a day.
+type templateData struct {
+	Day     int
+	Slug    string
+	Title   string
+	Package string
+	Input   string
+	Module  string
+}
+
+// file is a generated file, relative to the module root.
+type file struct {
+	path     string
+	template string
+	gofmt    bool
+}
+
+// Generate writes the files of day to the module rooted at root, together with
+// an empty puzzle input and golden file directory, and adds its package to
+// DaysFile. It returns the paths of the created and modified files,
+// relative to root. No file is written if any of the files to create already
+// exists.
+func Generate(root string, day Day) ([]string, error) {
+	if err := day.Validate(); err != nil {
+		return nil, err
+	}
+
+	module, err := modulePath(root)
+	if err != nil {
+		return nil, err
+	}
+
+	data := templateData{
+		Day:     day.Number,
+		Slug:    day.Slug,
+		Title:   strings.ReplaceAll(day.Slug, "-", " "),
+		Package: day.Package(),
+		Input:   day.Input(),
+		Module:  module,
+	}
+
+	pkgDir := filepath.Join("internal", "days", data.Package)
+	files := []file{
+		{filepath.Join("cmd", day.Slug, "solution", "main.go"), "main.go.tmpl", true},
+		{filepath.Join(pkgDir, data.Package+".go"), "day.go.tmpl", true},
+		{filepath.Join(pkgDir, data.Package+"_test.go"), "day_test.go.tmpl", true},
+		{filepath.Join("assets", data.Input), "", false},
+		// NOTE: keeps the golden file directory of the day, see package
+		// testutil, until its examples are added
+		{filepath.Join("testdata", day.Slug, ".gitkeep"), "", false},
+	}
+
+	contents := make([][]byte, len(files))
+	for idx, f := range files {
+		if _, err := os.Stat(filepath.Join(root, f.path)); err == nil {
+			return nil, fmt.Errorf("%s already exists", f.path)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+
+		if f.template == "" {
+			continue
+		}
+
+		contents[idx], err = render(f.template, data, f.gofmt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.path, err)
+		}
+	}
+
+	days, err := addImport(filepath.Join(root, DaysFile), module+"/internal/days/"+data.Package)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for idx, f := range files {
+		path := filepath.Join(root, f.path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, err
+		}
+
+		if err := os.WriteFile(path, contents[idx], 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, f.path)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, DaysFile), days, 0o644); err != nil {
+		return written, err
+	}
+
+	return append(written, DaysFile), nil
+}
+
+// render executes the named template with data, formatting the result as Go
+// source if gofmt is set.
+func render(name string, data any, gofmt bool) ([]byte, error) {
+	var out bytes.Buffer
+	if err := templates.ExecuteTemplate(&out, name, data); err != nil {
+		return nil, err
+	}
+
+	if !gofmt {
+		return out.Bytes(), nil
+	}
+
+	return format.Source(out.Bytes())
+}
+
+// addImport returns the content of the Go file at path with a blank import of
+// importPath added to its imports, which are kept sorted.
+func addImport(path string, importPath string) ([]byte, error) {
+	parsed, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read imports: %w", err)
+	}
+
+	var imports []string
+	for _, spec := range parsed.Imports {
+		imported, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		if imported == importPath {
+			return nil, fmt.Errorf("%s already imports %s", DaysFile, importPath)
+		}
+		imports = append(imports, imported)
+	}
+
+	imports = append(imports, importPath)
+	slices.Sort(imports)
+
+	return render("days.go.tmpl", imports, true)
+}
+
+// modulePath returns the module path declared in the go.mod file in root.
+func modulePath(root string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", fmt.Errorf("unable to read module: %w", err)
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`), nil
+		}
+	}
+
+	return "", fmt.Errorf("no module declared in %s", filepath.Join(root, "go.mod"))
+}
@@ -0,0 +1,141 @@
+package scaffold_test
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/scaffold"
+)
+
+const daysFile = `package main
+
+import (
+	_ "example.com/aoc/internal/days/historianhysteria"
+	_ "example.com/aoc/internal/days/rednosedreports"
+)
+`
+
+// newModule returns the root of a module containing only a go.mod file and
+// the days file.
+func newModule(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod":          "module example.com/aoc\n\ngo 1.23\n",
+		scaffold.DaysFile: daysFile,
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return root
+}
+
+func TestGenerate(t *testing.T) {
+	root := newModule(t)
+
+	written, err := scaffold.Generate(root, scaffold.Day{Number: 5, Slug: "print-queue"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		filepath.Join("cmd", "print-queue", "solution", "main.go"),
+		filepath.Join("internal", "days", "printqueue", "printqueue.go"),
+		filepath.Join("internal", "days", "printqueue", "printqueue_test.go"),
+		filepath.Join("assets", "print-queue.txt"),
+		filepath.Join("testdata", "print-queue", ".gitkeep"),
+		scaffold.DaysFile,
+	}
+	if !slices.Equal(written, want) {
+		t.Errorf("got %v, want %v", written, want)
+	}
+
+	for _, path := range written {
+		if filepath.Ext(path) != ".go" {
+			continue
+		}
+
+		if _, err := parser.ParseFile(token.NewFileSet(), filepath.Join(root, path), nil, 0); err != nil {
+			t.Errorf("generated invalid Go source: %v", err)
+		}
+	}
+
+	day, err := os.ReadFile(filepath.Join(root, "internal", "days", "printqueue", "printqueue.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"package printqueue", "Number:  5,", `Slug:    "print-queue",`, `Package: "printqueue",`, `"example.com/aoc/internal/puzzleio"`} {
+		if !strings.Contains(string(day), want) {
+			t.Errorf("expected generated day to contain %q, got:\n%s", want, day)
+		}
+	}
+
+	test, err := os.ReadFile(filepath.Join(root, "internal", "days", "printqueue", "printqueue_test.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"var tests = []struct {", `testutil.Golden(t, "print-queue")`} {
+		if !strings.Contains(string(test), want) {
+			t.Errorf("expected generated test to contain %q, got:\n%s", want, test)
+		}
+	}
+
+	days, err := os.ReadFile(filepath.Join(root, scaffold.DaysFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantImports := "\t_ \"example.com/aoc/internal/days/historianhysteria\"\n" +
+		"\t_ \"example.com/aoc/internal/days/printqueue\"\n" +
+		"\t_ \"example.com/aoc/internal/days/rednosedreports\"\n"
+	if !strings.Contains(string(days), wantImports) {
+		t.Errorf("expected sorted imports in days file, got:\n%s", days)
+	}
+
+	if _, err := scaffold.Generate(root, scaffold.Day{Number: 5, Slug: "print-queue"}); err == nil {
+		t.Error("expected error generating an existing day")
+	}
+}
+
+func TestGenerate_InvalidDay(t *testing.T) {
+	tests := []struct {
+		name string
+		day  scaffold.Day
+	}{
+		{"day out of range", scaffold.Day{Number: 26, Slug: "print-queue"}},
+		{"uppercase slug", scaffold.Day{Number: 5, Slug: "Print-Queue"}},
+		{"trailing dash", scaffold.Day{Number: 5, Slug: "print-"}},
+		{"keyword package", scaffold.Day{Number: 5, Slug: "go"}},
+		{"already imported", scaffold.Day{Number: 1, Slug: "historian-hysteria"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newModule(t)
+			if _, err := scaffold.Generate(root, tt.day); err == nil {
+				t.Fatal("expected error")
+			}
+
+			entries, err := os.ReadDir(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 2 {
+				t.Errorf("expected no files to be written, got %d entries", len(entries))
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package solution defines the interface every day of the calendar
+// implements, so runners, tests and benchmarks can parse an input once and
+// call each part individually.
+package solution
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ErrInvalidPart is returned when solving a part other than 1 or 2.
+var ErrInvalidPart = errors.New("invalid part, expected 1 or 2")
+
+// Solution solves both parts of a day's puzzle. Parse must be called before
+// Part1 or Part2; the parts must not modify the parsed input, so they can be
+// called repeatedly and in any order.
+type Solution interface {
+	// Parse reads the puzzle input.
+	Parse(input io.Reader) error
+	// Part1 returns the answer to the first part of the puzzle.
+	Part1() (Answer, error)
+	// Part2 returns the answer to the second part of the puzzle.
+	Part2() (Answer, error)
+}
+
+// Options are the runtime options of a solution, such as limits or words the
+// puzzle fixes but that are worth varying.
+type Options interface {
+	// Flags registers the options on flags, set to their current values,
+	// prefixing their names with prefix.
+	Flags(flags *flag.FlagSet, prefix string)
+	// Validate returns an error if the options cannot be used.
+	Validate() error
+	// New returns a new, unparsed solution using the options.
+	New() Solution
+}
+
+// Part calls Part1 or Part2 of s.
+func Part(s Solution, part int) (Answer, error) {
+	switch part {
+	case 1:
+		return s.Part1()
+	case 2:
+		return s.Part2()
+	}
+
+	return Answer{}, ErrInvalidPart
+}
+
+// Solve parses input with s and returns the answer to part.
+func Solve(s Solution, input io.Reader, part int) (Answer, error) {
+	if err := s.Parse(input); err != nil {
+		return Answer{}, err
+	}
+
+	return Part(s, part)
+}
+
+// Answer is the answer to a part of a puzzle. Most answers are integers, some
+// puzzles ask for text instead.
+type Answer struct {
+	num   int
+	text  string
+	isNum bool
+}
+
+// Int returns an integer answer.
+func Int(num int) Answer {
+	return Answer{num: num, isNum: true}
+}
+
+// Text returns a textual answer.
+func Text(text string) Answer {
+	return Answer{text: text}
+}
+
+// Int returns the answer as an integer, and false if it is not one.
+func (a Answer) Int() (int, bool) {
+	return a.num, a.isNum
+}
+
+// String returns the answer as it would be submitted.
+func (a Answer) String() string {
+	if a.isNum {
+		return strconv.Itoa(a.num)
+	}
+
+	return a.text
+}
+
+// MarshalJSON encodes integer answers as JSON numbers and all others as
+// strings.
+func (a Answer) MarshalJSON() ([]byte, error) {
+	if a.isNum {
+		return []byte(strconv.Itoa(a.num)), nil
+	}
+
+	return json.Marshal(a.text)
+}
+
+// UnmarshalJSON decodes JSON numbers into integer answers and strings into
+// textual answers.
+func (a *Answer) UnmarshalJSON(data []byte) error {
+	if num, err := strconv.Atoi(string(data)); err == nil {
+		*a = Int(num)
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("invalid answer %s: expected number or string", data)
+	}
+
+	*a = Text(text)
+	return nil
+}
@@ -0,0 +1,90 @@
+package solution_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// lineCount counts the lines of its input (part 1) and echoes the first one
+// (part 2).
+type lineCount struct {
+	lines []string
+}
+
+func (s *lineCount) Parse(input io.Reader) error {
+	data, err := io.ReadAll(input)
+	s.lines = strings.Split(strings.TrimSpace(string(data)), "\n")
+	return err
+}
+
+func (s *lineCount) Part1() (solution.Answer, error) {
+	return solution.Int(len(s.lines)), nil
+}
+
+func (s *lineCount) Part2() (solution.Answer, error) {
+	return solution.Text(s.lines[0]), nil
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		part    int
+		want    string
+		wantErr error
+	}{
+		{1, "3", nil},
+		{2, "XMAS", nil},
+		{3, "", solution.ErrInvalidPart},
+	}
+
+	for _, tt := range tests {
+		answer, err := solution.Solve(&lineCount{}, strings.NewReader("XMAS\nSAMX\nMMMS\n"), tt.part)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("part %d: got error %v, want %v", tt.part, err, tt.wantErr)
+		}
+
+		if answer.String() != tt.want {
+			t.Errorf("part %d: got %q, want %q", tt.part, answer, tt.want)
+		}
+	}
+}
+
+func TestAnswer_JSON(t *testing.T) {
+	tests := []struct {
+		answer solution.Answer
+		want   string
+	}{
+		{solution.Int(2904518), `2904518`},
+		{solution.Int(-3), `-3`},
+		{solution.Text("6,0"), `"6,0"`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.answer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if string(data) != tt.want {
+			t.Errorf("got %s, want %s", data, tt.want)
+		}
+
+		var decoded solution.Answer
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if decoded != tt.answer {
+			t.Errorf("got %#v after round trip, want %#v", decoded, tt.answer)
+		}
+	}
+
+	var invalid solution.Answer
+	if err := json.Unmarshal([]byte(`[1]`), &invalid); err == nil {
+		t.Error("expected error decoding array as answer")
+	}
+}
@@ -0,0 +1,122 @@
+// Package standalone runs a single registered day as a command of its own,
+// e.g. cmd/ceres-search/solution. It takes the --input flag, the runtime
+// options of the day without a prefix and the profiling flags, all of which
+// can also be set from the environment or config file, see package config.
+package standalone
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lo-b/aoc24/internal/config"
+	"github.com/lo-b/aoc24/internal/profiling"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// Main runs the day registered as slug with the arguments of the process and
+// returns its exit code, see Run.
+func Main(slug string, labels [2]string) int {
+	day, ok := registry.LookupSlug(slug)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "no day registered as %q\n", slug)
+		return 2
+	}
+
+	return Run(day, labels, os.Args[1:], os.Stdout, os.Stderr)
+}
+
+// Run parses args, solves both parts of day and writes their answers to
+// stdout, each preceded by its label. It returns the exit code of the
+// process: 2 for invalid arguments and 1 when the day cannot be solved.
+func Run(day registry.Day, labels [2]string, args []string, stdout io.Writer, stderr io.Writer) int {
+	flags := flag.NewFlagSet(day.Slug, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	inputPath := flags.String("input", "", "path to puzzle input, '-' reads from stdin")
+	var profiles profiling.Options
+	profiles.Flags(flags)
+
+	var options solution.Options
+	if day.Options != nil {
+		options = day.Options()
+		options.Flags(flags, "")
+	}
+	config.Register(flags, day.Slug)
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if err := config.Apply(flags, day.Slug); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+
+	newSolution := day.New
+	if options != nil {
+		if err := options.Validate(); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+		newSolution = options.New
+	}
+
+	puzzleInput, source, err := puzzleio.Resolve(*inputPath, day.Input)
+	if err != nil {
+		fmt.Fprintln(stderr, "Unable to read input file")
+		fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+	defer puzzleInput.Close()
+	fmt.Fprintf(stderr, "reading puzzle input from %s (%v)\n", puzzleInput.Path, source)
+
+	// NOTE: profiles cover parsing and solving only, not resolving or
+	// fingerprinting the input
+	sol := newSolution()
+	stopProfiles, err := profiles.Start()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	answers, err := solve(sol, puzzleInput)
+	err = errors.Join(err, stopProfiles())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	fingerprint, err := puzzleInput.Fingerprint()
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	for idx, answer := range answers {
+		fmt.Fprintf(stdout, "%s: %v\n", labels[idx], answer)
+	}
+	fmt.Fprintf(stdout, "input fingerprint: %s\n", fingerprint.Short())
+
+	return 0
+}
+
+// solve parses puzzleInput with sol and returns the answers to both parts.
+func solve(sol solution.Solution, puzzleInput *puzzleio.PuzzleInput) ([2]solution.Answer, error) {
+	var answers [2]solution.Answer
+	if err := sol.Parse(puzzleInput); err != nil {
+		return answers, puzzleio.WithPath(err, puzzleInput.Path)
+	}
+
+	for idx := range answers {
+		answer, err := solution.Part(sol, idx+1)
+		if err != nil {
+			return answers, fmt.Errorf("part %d: %w", idx+1, err)
+		}
+		answers[idx] = answer
+	}
+
+	return answers, nil
+}
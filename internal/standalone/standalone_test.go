@@ -0,0 +1,57 @@
+package standalone_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/lo-b/aoc24/internal/days/rednosedreports"
+	"github.com/lo-b/aoc24/internal/modroot"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/standalone"
+	"github.com/lo-b/aoc24/internal/testutil"
+)
+
+func TestRun(t *testing.T) {
+	root, err := modroot.Find(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	example := testutil.InputPath(testutil.Dir(root, "red-nosed-reports"), "example")
+
+	day, ok := registry.LookupSlug("red-nosed-reports")
+	if !ok {
+		t.Fatal("red-nosed-reports is not registered")
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout []string
+	}{
+		{"site example", []string{"--input", example}, 0, []string{"safe: 2\n", "dampened: 4\n", "input fingerprint: "}},
+		{"day option", []string{"--input", example, "--max-level-diff", "5"}, 0, []string{"safe: 4\n", "dampened: 6\n"}},
+		{"invalid day option", []string{"--input", example, "--max-level-diff", "0"}, 2, nil},
+		{"unknown flag", []string{"--part", "1"}, 2, nil},
+		{"missing input", []string{"--input", filepath.Join(t.TempDir(), "reports.txt")}, 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := standalone.Run(day, [2]string{"safe", "dampened"}, tt.args, &stdout, &stderr)
+
+			if code != tt.wantCode {
+				t.Errorf("got exit code %d, want %d (stderr: %s)", code, tt.wantCode, stderr.String())
+			}
+
+			for _, want := range tt.wantStdout {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("expected stdout to contain %q, got:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+// Package submit posts answers to an AoC-compatible HTTP endpoint and
+// interprets its responses. The cooldown the endpoint imposes after a wrong
+// answer is remembered on disk and enforced locally, so no request is sent
+// before it has passed.
+package submit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/puzzlepage"
+)
+
+// Outcome is the verdict of the endpoint on a submitted answer.
+type Outcome int
+
+const (
+	Unknown    Outcome = iota // response not understood
+	Correct                   // the right answer
+	TooHigh                   // wrong, the answer is too high
+	TooLow                    // wrong, the answer is too low
+	Incorrect                 // wrong, without a hint
+	TooRecent                 // not checked, an answer was given too recently
+	WrongLevel                // not checked, the part is locked or already solved
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Correct:
+		return "correct"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case Incorrect:
+		return "incorrect"
+	case TooRecent:
+		return "too recent"
+	case WrongLevel:
+		return "wrong level"
+	}
+
+	return "unknown"
+}
+
+// Result is the parsed response to a submitted answer.
+type Result struct {
+	Outcome Outcome
+	Wait    time.Duration // cooldown before the next answer may be submitted
+	Message string        // text of the response
+}
+
+var (
+	spacePattern  = regexp.MustCompile(`\s+`)
+	leftPattern   = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+	minutePattern = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+// ParseResponse interprets the HTML page returned for a submitted answer.
+func ParseResponse(page string) Result {
+	message := page
+	if articles := puzzlepage.Articles(page); len(articles) > 0 {
+		message = articles[0]
+	}
+	message = spacePattern.ReplaceAllString(puzzlepage.Text(message), " ")
+
+	result := Result{Message: message, Wait: parseWait(message)}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		result.Outcome = Correct
+	case strings.Contains(message, "You gave an answer too recently"):
+		result.Outcome = TooRecent
+	case strings.Contains(message, "You don't seem to be solving the right level"):
+		result.Outcome = WrongLevel
+	case strings.Contains(message, "your answer is too high"):
+		result.Outcome = TooHigh
+	case strings.Contains(message, "your answer is too low"):
+		result.Outcome = TooLow
+	case strings.Contains(message, "That's not the right answer"):
+		result.Outcome = Incorrect
+	}
+
+	return result
+}
+
+// parseWait returns the cooldown mentioned in message, or zero if there is
+// none.
+func parseWait(message string) time.Duration {
+	if match := leftPattern.FindStringSubmatch(message); match != nil {
+		minutes, _ := strconv.Atoi(match[1])
+		seconds, _ := strconv.Atoi(match[2])
+
+		return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	}
+
+	if match := minutePattern.FindStringSubmatch(message); match != nil {
+		if match[1] == "one" {
+			return time.Minute
+		}
+
+		minutes, _ := strconv.Atoi(match[1])
+		return time.Duration(minutes) * time.Minute
+	}
+
+	return 0
+}
+
+// CooldownError is returned when an answer is submitted before the cooldown
+// of a previous answer has passed.
+type CooldownError struct {
+	Until     time.Time
+	Remaining time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("cooldown until %s, wait %v before submitting", e.Until.Format(time.TimeOnly), e.Remaining.Round(time.Second))
+}
+
+// Submitter posts answers to an AoC-compatible endpoint. It keeps the time
+// until which submissions are blocked, per year and day, in StateFile.
+type Submitter struct {
+	BaseURL   string // endpoint, e.g. puzzleio.DefaultBaseURL
+	Session   string // value of the 'session' cookie
+	StateFile string // file the cooldowns are kept in
+	UserAgent string // User-Agent header sent with every request
+	Client    *http.Client
+	Now       func() time.Time // current time, time.Now when nil
+
+	mu sync.Mutex
+}
+
+// NewSubmitter creates a Submitter for baseURL, using the session token stored
+// in sessionFile and keeping cooldowns in stateFile.
+func NewSubmitter(baseURL string, sessionFile string, stateFile string) (*Submitter, error) {
+	session, err := puzzleio.ReadSession(sessionFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Submitter{
+		BaseURL:   strings.TrimSuffix(baseURL, "/"),
+		Session:   session,
+		StateFile: stateFile,
+		UserAgent: puzzleio.DefaultUserAgent,
+		Client:    http.DefaultClient,
+	}, nil
+}
+
+// DefaultStateFile returns the file cooldowns are kept in by default, i.e.
+// 'submit.json' in the input cache directory.
+func DefaultStateFile() (string, error) {
+	dir, err := puzzleio.DefaultCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "submit.json"), nil
+}
+
+// Submit posts answer to part of year and day. It returns a *CooldownError
+// without sending a request while a previous cooldown has not passed.
+func (s *Submitter) Submit(ctx context.Context, year int, day int, part int, answer string) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cooldowns, err := s.loadCooldowns()
+	if err != nil {
+		return Result{}, err
+	}
+
+	key := fmt.Sprintf("%d/%d", year, day)
+	if until, ok := cooldowns[key]; ok && s.now().Before(until) {
+		return Result{}, &CooldownError{Until: until, Remaining: until.Sub(s.now())}
+	}
+
+	page, err := s.post(ctx, year, day, part, answer)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := ParseResponse(page)
+	if result.Wait > 0 {
+		cooldowns[key] = s.now().Add(result.Wait)
+	} else {
+		delete(cooldowns, key)
+	}
+
+	if err := s.saveCooldowns(cooldowns); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// post sends answer to the endpoint and returns the response page.
+func (s *Submitter) post(ctx context.Context, year int, day int, part int, answer string) (string, error) {
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", s.BaseURL, year, day)
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", s.UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: s.Session})
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to submit answer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to submit answer: %s returned %s", endpoint, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("unable to submit answer: %w", err)
+	}
+
+	return string(body), nil
+}
+
+func (s *Submitter) now() time.Time {
+	if s.Now == nil {
+		return time.Now()
+	}
+
+	return s.Now()
+}
+
+// loadCooldowns reads the cooldowns, keyed by "year/day", from StateFile.
+func (s *Submitter) loadCooldowns() (map[string]time.Time, error) {
+	cooldowns := make(map[string]time.Time)
+
+	data, err := os.ReadFile(s.StateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cooldowns, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read cooldowns: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cooldowns); err != nil {
+		return nil, fmt.Errorf("unable to read cooldowns %s: %w", s.StateFile, err)
+	}
+
+	return cooldowns, nil
+}
+
+// saveCooldowns writes cooldowns to StateFile.
+func (s *Submitter) saveCooldowns(cooldowns map[string]time.Time) error {
+	data, err := json.MarshalIndent(cooldowns, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.StateFile), 0o755); err != nil {
+		return fmt.Errorf("unable to save cooldowns: %w", err)
+	}
+
+	if err := os.WriteFile(s.StateFile, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("unable to save cooldowns: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package submit_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/submit"
+	"github.com/lo-b/aoc24/internal/submit/submittest"
+)
+
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     string
+		want     submit.Outcome
+		wantWait time.Duration
+	}{
+		{
+			name: "correct",
+			page: "<main><article><p>That's the right answer! You are one gold star closer.</p></article></main>",
+			want: submit.Correct,
+		},
+		{
+			name:     "too high",
+			page:     "<article><p>That's not the right answer; your answer is too high.  Please wait one minute before trying again.</p></article>",
+			want:     submit.TooHigh,
+			wantWait: time.Minute,
+		},
+		{
+			name:     "too low",
+			page:     "<article><p>That's not the right answer; your answer is too low. Please wait 5 minutes before trying again.</p></article>",
+			want:     submit.TooLow,
+			wantWait: 5 * time.Minute,
+		},
+		{
+			name:     "incorrect without hint",
+			page:     "<article><p>That&#39;s not the right answer. Please\nwait one minute before trying again.</p></article>",
+			want:     submit.Incorrect,
+			wantWait: time.Minute,
+		},
+		{
+			name:     "too recent",
+			page:     "<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 5s left to wait.</p></article>",
+			want:     submit.TooRecent,
+			wantWait: time.Minute + 5*time.Second,
+		},
+		{
+			name:     "too recent in seconds",
+			page:     "<article><p>You gave an answer too recently. You have 34s left to wait.</p></article>",
+			want:     submit.TooRecent,
+			wantWait: 34 * time.Second,
+		},
+		{
+			name: "wrong level",
+			page: "<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>",
+			want: submit.WrongLevel,
+		},
+		{
+			name: "unknown",
+			page: "<html>Please log in.</html>",
+			want: submit.Unknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := submit.ParseResponse(tt.page)
+			if got.Outcome != tt.want || got.Wait != tt.wantWait {
+				t.Errorf("got %v (wait %v), want %v (wait %v); message %q", got.Outcome, got.Wait, tt.want, tt.wantWait, got.Message)
+			}
+		})
+	}
+}
+
+func newTestSubmitter(t *testing.T, baseURL string, session string) *submit.Submitter {
+	t.Helper()
+
+	sessionFile := filepath.Join(t.TempDir(), "session")
+	if err := os.WriteFile(sessionFile, []byte(session+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	submitter, err := submit.NewSubmitter(baseURL, sessionFile, filepath.Join(t.TempDir(), "submit.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return submitter
+}
+
+func TestSubmitter_Submit(t *testing.T) {
+	server := submittest.NewServer(t, "secret", map[submittest.Key]string{
+		{Year: 2024, Day: 1, Part: 1}: "11",
+		{Year: 2024, Day: 1, Part: 2}: "31",
+	})
+	submitter := newTestSubmitter(t, server.URL, "secret")
+
+	now := time.Date(2024, 12, 1, 6, 0, 0, 0, time.UTC)
+	submitter.Now = func() time.Time { return now }
+
+	ctx := context.Background()
+	result, err := submitter.Submit(ctx, 2024, 1, 1, "12")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Outcome != submit.TooHigh || result.Wait != submittest.Cooldown {
+		t.Errorf("got %v (wait %v), want %v (wait %v)", result.Outcome, result.Wait, submit.TooHigh, submittest.Cooldown)
+	}
+
+	// NOTE: the cooldown is enforced without sending a request
+	_, err = submitter.Submit(ctx, 2024, 1, 1, "11")
+	var cooldown *submit.CooldownError
+	if !errors.As(err, &cooldown) {
+		t.Fatalf("got error %v, want a cooldown error", err)
+	}
+	if cooldown.Remaining != submittest.Cooldown {
+		t.Errorf("got remaining %v, want %v", cooldown.Remaining, submittest.Cooldown)
+	}
+	if server.Requests() != 1 {
+		t.Errorf("got %d requests, want 1", server.Requests())
+	}
+
+	// NOTE: the stand-in keeps its own cooldown; a submitter that ignores
+	// it is told to wait
+	other := newTestSubmitter(t, server.URL, "secret")
+	result, err = other.Submit(ctx, 2024, 1, 1, "11")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Outcome != submit.TooRecent || result.Wait <= 0 {
+		t.Errorf("got %v (wait %v), want %v with a wait", result.Outcome, result.Wait, submit.TooRecent)
+	}
+
+	now = now.Add(submittest.Cooldown)
+	result, err = submitter.Submit(ctx, 2024, 1, 1, "11")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Outcome != submit.TooRecent {
+		t.Errorf("got %v, want %v", result.Outcome, submit.TooRecent)
+	}
+}
+
+func TestSubmitter_SubmitCorrect(t *testing.T) {
+	server := submittest.NewServer(t, "secret", map[submittest.Key]string{
+		{Year: 2024, Day: 1, Part: 1}: "11",
+		{Year: 2024, Day: 1, Part: 2}: "31",
+	})
+	submitter := newTestSubmitter(t, server.URL, "secret")
+	ctx := context.Background()
+
+	tests := []struct {
+		part   int
+		answer string
+		want   submit.Outcome
+	}{
+		{2, "31", submit.WrongLevel},
+		{1, "11", submit.Correct},
+		{1, "11", submit.WrongLevel},
+		{2, "31", submit.Correct},
+	}
+
+	for _, tt := range tests {
+		result, err := submitter.Submit(ctx, 2024, 1, tt.part, tt.answer)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Outcome != tt.want {
+			t.Errorf("part %d answer %s: got %v, want %v", tt.part, tt.answer, result.Outcome, tt.want)
+		}
+	}
+
+	bad := newTestSubmitter(t, server.URL, "wrong")
+	if _, err := bad.Submit(ctx, 2024, 1, 1, "11"); err == nil {
+		t.Error("expected error submitting with an invalid session")
+	}
+}
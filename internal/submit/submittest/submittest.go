@@ -0,0 +1,120 @@
+// Package submittest provides a stand-in for the answer endpoint of an
+// AoC-compatible server, for use in tests.
+package submittest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Cooldown is the time a wrong answer blocks further answers to a day.
+const Cooldown = time.Minute
+
+// Key identifies a part of a puzzle.
+type Key struct {
+	Year, Day, Part int
+}
+
+// Server mimics the answer endpoint: it checks the session cookie, compares
+// answers with the correct ones, hints whether numeric answers are too high or
+// too low and imposes a cooldown after every wrong answer.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	session   string
+	answers   map[Key]string
+	solved    map[Key]bool
+	cooldowns map[Key]time.Time // by year and day, with a zero part
+	requests  int
+}
+
+// NewServer starts a Server accepting session and knowing the correct
+// answers. It is closed when the test ends.
+func NewServer(t *testing.T, session string, answers map[Key]string) *Server {
+	t.Helper()
+
+	s := &Server{
+		session:   session,
+		answers:   answers,
+		solved:    make(map[Key]bool),
+		cooldowns: make(map[Key]time.Time),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /{year}/day/{day}/answer", s.handleAnswer)
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// Requests returns the number of answers submitted to s.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) handleAnswer(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+
+	if cookie, err := r.Cookie("session"); err != nil || cookie.Value != s.session {
+		http.Error(w, "Please log in.", http.StatusBadRequest)
+		return
+	}
+
+	year, _ := strconv.Atoi(r.PathValue("year"))
+	day, _ := strconv.Atoi(r.PathValue("day"))
+	part, _ := strconv.Atoi(r.FormValue("level"))
+	key := Key{Year: year, Day: day, Part: part}
+	dayKey := Key{Year: year, Day: day}
+
+	if left := time.Until(s.cooldowns[dayKey]); left > 0 {
+		article(w, fmt.Sprintf("You gave an answer too recently; you have to wait after submitting an answer before trying again. You have %dm %ds left to wait.",
+			int(left.Minutes()), int(left.Seconds())%60))
+		return
+	}
+
+	want, ok := s.answers[key]
+	if !ok || s.solved[key] || (part == 2 && !s.solved[Key{Year: year, Day: day, Part: 1}]) {
+		article(w, "You don't seem to be solving the right level. Did you already complete it?")
+		return
+	}
+
+	got := r.FormValue("answer")
+	if got == want {
+		s.solved[key] = true
+		article(w, "That's the right answer! You are one gold star closer to finding the Chief Historian.")
+		return
+	}
+
+	s.cooldowns[dayKey] = time.Now().Add(Cooldown)
+
+	hint := ""
+	gotNum, gotErr := strconv.Atoi(got)
+	wantNum, wantErr := strconv.Atoi(want)
+	if gotErr == nil && wantErr == nil {
+		if gotNum > wantNum {
+			hint = "; your answer is too high"
+		} else {
+			hint = "; your answer is too low"
+		}
+	}
+
+	article(w, fmt.Sprintf("That's not the right answer%s. If you're stuck, make sure you're using the full input data. Please wait one minute before trying again.", hint))
+}
+
+// article writes message as the response page, wrapped like the real one.
+func article(w http.ResponseWriter, message string) {
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html><body><main>\n<article><p>%s <a href=\"/\">[Return]</a></p></article>\n</main></body></html>\n", message)
+}
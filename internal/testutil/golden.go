@@ -0,0 +1,175 @@
+// Package testutil runs the solutions of the days against the golden files in
+// the testdata directory of the repository.
+//
+// Every day has a directory testdata/<slug> holding puzzle inputs, e.g.
+// example.in, next to the expected answers to each part, example.part1 and
+// example.part2; 'aoc examples' writes them from the puzzle page. A part
+// without a golden file is skipped. Run the tests of a day with -update to
+// write the answers of all parts to the golden files:
+//
+//	go test ./internal/days/ceressearch -update
+package testutil
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/modroot"
+	"github.com/lo-b/aoc24/internal/puzzleio"
+	"github.com/lo-b/aoc24/internal/registry"
+	"github.com/lo-b/aoc24/internal/solution"
+)
+
+// TestdataDir is the directory, relative to the repository root, holding the
+// golden files of all days.
+const TestdataDir = "testdata"
+
+const (
+	inputExt  = ".in"
+	goldenExt = ".part"
+)
+
+var update = flag.Bool("update", false, "write the answers of the solutions to the golden files")
+
+// Dir returns the directory of the golden files of the day named slug in the
+// repository at root.
+func Dir(root string, slug string) string {
+	return filepath.Join(root, TestdataDir, slug)
+}
+
+// InputPath returns the path of the puzzle input of the case name in dir.
+func InputPath(dir string, name string) string {
+	return filepath.Join(dir, name+inputExt)
+}
+
+// GoldenPath returns the path of the expected answer to part of the case name
+// in dir.
+func GoldenPath(dir string, name string, part int) string {
+	return filepath.Join(dir, fmt.Sprintf("%s%s%d", name, goldenExt, part))
+}
+
+// Cases returns the names of the puzzle inputs in dir, sorted.
+func Cases(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), inputExt); ok && entry.Type().IsRegular() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// WriteCase writes input as the case name in dir, together with the golden
+// files of the non-empty answers, indexed by part minus one.
+func WriteCase(dir string, name string, input string, answers [2]string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(InputPath(dir, name), []byte(input), 0o644); err != nil {
+		return err
+	}
+
+	for idx, answer := range answers {
+		if answer == "" {
+			continue
+		}
+
+		if err := os.WriteFile(GoldenPath(dir, name, idx+1), []byte(answer+"\n"), 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Golden runs the day registered as slug against every case in its testdata
+// directory, as a subtest per case and part. It skips the test when there are
+// no cases yet.
+func Golden(t *testing.T, slug string) {
+	t.Helper()
+
+	day, ok := registry.LookupSlug(slug)
+	if !ok {
+		t.Fatalf("no day registered as %q", slug)
+	}
+
+	root, err := modroot.Find(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := Dir(root, slug)
+	names, err := Cases(dir)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Skipf("no cases in %s, add <name>%s and its golden files", dir, inputExt)
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			runCase(t, day, dir, name)
+		})
+	}
+}
+
+// runCase parses the input of the case name once and checks the answers to
+// both parts against their golden files. The input is read like puzzle inputs
+// are, see puzzleio.Normalize.
+func runCase(t *testing.T, day registry.Day, dir string, name string) {
+	input, err := puzzleio.NewPuzzleInput(InputPath(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer input.Close()
+
+	sol := day.New()
+	if err := sol.Parse(input.Reader); err != nil {
+		t.Fatalf("unexpected error: %v", puzzleio.WithPath(err, input.Path))
+	}
+
+	for _, part := range []int{1, 2} {
+		t.Run(fmt.Sprintf("part%d", part), func(t *testing.T) {
+			goldenPath := GoldenPath(dir, name, part)
+			want, err := os.ReadFile(goldenPath)
+			if errors.Is(err, fs.ErrNotExist) && !*update {
+				t.Skipf("no golden file %s", filepath.Base(goldenPath))
+			} else if err != nil && !*update {
+				t.Fatal(err)
+			}
+
+			answer, err := solution.Part(sol, part)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := answer.String() + "\n"
+			if *update {
+				if err := os.WriteFile(goldenPath, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			if got != string(want) {
+				t.Errorf("answer differs from %s (-want +got):\n%s", filepath.Base(goldenPath), Diff(string(want), got))
+			}
+		})
+	}
+}
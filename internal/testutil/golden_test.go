@@ -0,0 +1,65 @@
+package testutil_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/lo-b/aoc24/internal/testutil"
+)
+
+func TestDiff(t *testing.T) {
+	var tests = []struct {
+		name string
+		want string
+		got  string
+		diff string
+	}{
+		{"single line", "11\n", "12\n", "- 11\n+ 12\n"},
+		{"equal", "11\n", "11\n", "  11\n"},
+		{"changed row", "#.\n.#\n##\n", "#.\n..\n##\n", "  #.\n- .#\n+ ..\n  ##\n"},
+		{"added row", "a\nc\n", "a\nb\nc\n", "  a\n+ b\n  c\n"},
+		{"missing newline", "a\n", "a", "  a\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := testutil.Diff(tt.want, tt.got); diff != tt.diff {
+				t.Errorf("got %q, want %q", diff, tt.diff)
+			}
+		})
+	}
+}
+
+func TestWriteCase(t *testing.T) {
+	dir := testutil.Dir(t.TempDir(), "ceres-search")
+
+	if err := testutil.WriteCase(dir, "example", "XMAS\n", [2]string{"1", ""}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := testutil.WriteCase(dir, "another", "SAMX\n", [2]string{"1", "0"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names, err := testutil.Cases(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"another", "example"}; !slices.Equal(names, want) {
+		t.Errorf("got %v, want %v", names, want)
+	}
+
+	golden, err := os.ReadFile(testutil.GoldenPath(dir, "example", 1))
+	if err != nil || string(golden) != "1\n" {
+		t.Errorf("got %q (error: %v), want %q", golden, err, "1\n")
+	}
+
+	if _, err := os.Stat(testutil.GoldenPath(dir, "example", 2)); !os.IsNotExist(err) {
+		t.Errorf("expected no golden file for an empty answer, got %v", err)
+	}
+
+	if got, want := testutil.InputPath(dir, "example"), filepath.Join(dir, "example.in"); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+// Package watch polls files for changes. Polling needs no platform specific
+// notification API and is cheap for the handful of files of a day.
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// FileState is the state of a file used to detect changes.
+type FileState struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// Snapshot holds the state of the watched files, keyed by path.
+type Snapshot map[string]FileState
+
+// Take returns the state of paths. A directory stands for the regular files
+// directly inside it; missing paths are left out, so creating them is a
+// change.
+func Take(paths []string) (Snapshot, error) {
+	snapshot := make(Snapshot)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			snapshot[path] = FileState{ModTime: info.ModTime(), Size: info.Size()}
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if !entry.Type().IsRegular() {
+				continue
+			}
+
+			info, err := entry.Info()
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			snapshot[filepath.Join(path, entry.Name())] = FileState{ModTime: info.ModTime(), Size: info.Size()}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// Changed returns the sorted paths that were created, removed or modified
+// between s and next.
+func (s Snapshot) Changed(next Snapshot) []string {
+	var changed []string
+	for path, state := range next {
+		if previous, ok := s[path]; !ok || !previous.ModTime.Equal(state.ModTime) || previous.Size != state.Size {
+			changed = append(changed, path)
+		}
+	}
+
+	for path := range s {
+		if _, ok := next[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+
+	slices.Sort(changed)
+	return changed
+}
+
+// Poll takes a snapshot of paths every interval and calls fn with the changed
+// paths whenever they differ from the previous snapshot. It returns when ctx
+// is done or taking a snapshot fails.
+func Poll(ctx context.Context, paths []string, interval time.Duration, fn func(changed []string)) error {
+	previous, err := Take(paths)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		next, err := Take(paths)
+		if err != nil {
+			return err
+		}
+
+		if changed := previous.Changed(next); len(changed) > 0 {
+			fn(changed)
+		}
+		previous = next
+	}
+}
@@ -0,0 +1,96 @@
+package watch_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/lo-b/aoc24/internal/watch"
+)
+
+func writeFile(t *testing.T, path string, content string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshot_Changed(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(t.TempDir(), "input.txt")
+	start := time.Date(2024, 12, 1, 6, 0, 0, 0, time.UTC)
+
+	writeFile(t, filepath.Join(dir, "day.go"), "package day", start)
+	writeFile(t, filepath.Join(dir, "day_test.go"), "package day", start)
+	writeFile(t, input, "3   4\n", start)
+	if err := os.Mkdir(filepath.Join(dir, "testdata"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := []string{dir, input, filepath.Join(dir, "missing.txt")}
+	before, err := watch.Take(paths)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(before) != 3 {
+		t.Errorf("got %d files, want 3: %v", len(before), before)
+	}
+
+	writeFile(t, filepath.Join(dir, "day.go"), "package day", start.Add(time.Second))
+	writeFile(t, input, "3   4\n4   3\n", start)
+	writeFile(t, filepath.Join(dir, "missing.txt"), "", start)
+	if err := os.Remove(filepath.Join(dir, "day_test.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	after, err := watch.Take(paths)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "day.go"), filepath.Join(dir, "day_test.go"), filepath.Join(dir, "missing.txt"), input}
+	slices.Sort(want)
+	if got := before.Changed(after); !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got := after.Changed(after); len(got) != 0 {
+		t.Errorf("got %v, want no changes", got)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "input.txt")
+	writeFile(t, input, "3   4\n", time.Date(2024, 12, 1, 6, 0, 0, 0, time.UTC))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		if err := os.WriteFile(input, []byte("3   4\n4   3\n"), 0o644); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	err := watch.Poll(ctx, []string{input}, 5*time.Millisecond, func(changed []string) {
+		got = changed
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+
+	if !slices.Equal(got, []string{input}) {
+		t.Errorf("got %v, want %v", got, []string{input})
+	}
+}